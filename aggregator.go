@@ -3,41 +3,76 @@ package graphmetrics
 import (
 	"time"
 
+	"github.com/graphmetrics/graphmetrics-go/client"
 	"github.com/graphmetrics/graphmetrics-go/internal/conversion"
 	"github.com/graphmetrics/graphmetrics-go/internal/models"
+	"github.com/graphmetrics/graphmetrics-go/signature"
 
 	"github.com/graphmetrics/logger-go"
 )
 
-const flushInterval = 1 * time.Minute
+const (
+	flushInterval = 1 * time.Minute
+	otherValue    = client.Other
+)
 
 type Aggregator struct {
 	metrics         *models.UsageMetrics
 	definitions     *models.UsageDefinitions
 	knownOperations map[string]bool
 	serverVersion   string
+	clientsChecked  bool
+	maxPayloadSize  int
+	sketches        models.SketchConfig
+
+	clientNormalizer   client.Normalizer
+	dimensions         *limiter
+	dimensionValues    map[string]*limiter
+	maxDimensionValues int
+	contexts           *limiter
+	operations         *limiter
+	fields             *limiter
+	maxTenants         int
+	tenantExporter     TenantExporter
+	usageExporter      UsageExporter
 
-	flushTicker   *time.Ticker
-	fieldChan     chan *FieldMessage
-	operationChan chan *OperationMessage
-	stopChan      chan interface{}
-	sender        *Sender
+	flushTicker      *time.Ticker
+	fieldChan        chan *FieldMessage
+	operationChan    chan *OperationMessage
+	subscriptionChan chan *SubscriptionMessage
+	stopChan         chan interface{}
+	sender           *Sender
 
 	logger logger.Logger
 }
 
 func NewAggregator(cfg *Configuration) *Aggregator {
 	return &Aggregator{
-		metrics:         models.NewUsageMetrics(),
+		metrics:         models.NewUsageMetricsWithSketches(cfg.GetSketchConfig()),
 		definitions:     models.NewUsageDefinitions(),
 		knownOperations: make(map[string]bool, 10),
 		serverVersion:   cfg.ServerVersion,
-		flushTicker:     time.NewTicker(flushInterval),
-		fieldChan:       make(chan *FieldMessage, cfg.GetFieldBufferSize()),
-		operationChan:   make(chan *OperationMessage, cfg.GetOperationBufferSize()),
-		stopChan:        make(chan interface{}),
-		sender:          NewSender(cfg),
-		logger:          cfg.GetLogger(),
+		maxPayloadSize:  cfg.GetMaxPayloadSize(),
+		sketches:        cfg.GetSketchConfig(),
+
+		clientNormalizer:   cfg.ClientNormalizer,
+		dimensions:         newLimiter(cfg.GetMaxDimensions()),
+		dimensionValues:    make(map[string]*limiter),
+		maxDimensionValues: cfg.GetMaxDimensionValues(),
+		contexts:           newLimiter(cfg.GetMaxContexts()),
+		operations:         newLimiter(cfg.GetMaxOperations()),
+		fields:             newLimiter(cfg.GetMaxFields()),
+		maxTenants:         cfg.GetMaxTenants(),
+		tenantExporter:     cfg.TenantExporter,
+		usageExporter:      cfg.UsageExporter,
+
+		flushTicker:      time.NewTicker(flushInterval),
+		fieldChan:        make(chan *FieldMessage, cfg.GetFieldBufferSize()),
+		operationChan:    make(chan *OperationMessage, cfg.GetOperationBufferSize()),
+		subscriptionChan: make(chan *SubscriptionMessage, cfg.GetOperationBufferSize()),
+		stopChan:         make(chan interface{}),
+		sender:           NewSender(cfg),
+		logger:           cfg.GetLogger(),
 	}
 }
 
@@ -50,6 +85,8 @@ func (a *Aggregator) Start() {
 			a.flush()
 		case o := <-a.operationChan:
 			a.processOperation(o)
+		case s := <-a.subscriptionChan:
+			a.processSubscription(s)
 		case f := <-a.fieldChan:
 			a.processField(f)
 			break
@@ -68,6 +105,10 @@ func (a *Aggregator) Stop() error {
 	for msg := range a.operationChan {
 		a.processOperation(msg)
 	}
+	close(a.subscriptionChan)
+	for msg := range a.subscriptionChan {
+		a.processSubscription(msg)
+	}
 	a.flush()
 	return a.sender.Stop()
 }
@@ -93,11 +134,30 @@ func (a *Aggregator) PushOperation(msg *OperationMessage) {
 	}
 }
 
+func (a *Aggregator) PushSubscription(msg *SubscriptionMessage) {
+	select {
+	case a.subscriptionChan <- msg:
+		return
+	default:
+		a.logger.Warn("graphmetrics aggregator subscription buffer overflowing, dropping message", nil)
+	}
+}
+
 func (a *Aggregator) processField(msg *FieldMessage) {
 	// Find field metrics
-	metrics := a.metrics.FindContextMetrics(msg.Client.Name, msg.Client.Version, a.serverVersion)
-	typeMetrics := metrics.FindTypeMetrics(msg.TypeName)
-	fieldMetrics := typeMetrics.FindFieldMetrics(msg.FieldName)
+	metrics := a.metrics.FindContextMetrics(a.metricsContext(msg.Client))
+	typeName, fieldName, returnType := msg.TypeName, msg.FieldName, msg.ReturnType
+	if !a.fields.allow(typeName + "." + fieldName) {
+		typeName, fieldName, returnType = otherValue, otherValue, ""
+		a.metrics.Overflow.Fields += 1
+	}
+	typeMetrics := metrics.FindTypeMetrics(typeName)
+	var fieldMetrics *models.FieldMetrics
+	if msg.List {
+		fieldMetrics = typeMetrics.FindListFieldMetrics(fieldName)
+	} else {
+		fieldMetrics = typeMetrics.FindFieldMetrics(fieldName)
+	}
 
 	// Insert message
 	err := fieldMetrics.Histogram.Add(float64(msg.Duration))
@@ -110,15 +170,43 @@ func (a *Aggregator) processField(msg *FieldMessage) {
 		})
 		return
 	}
+	if msg.List {
+		_ = fieldMetrics.ListLengthHistogram.Add(float64(msg.ListLength))
+	}
+	for _, enumValue := range msg.EnumValues {
+		metrics.ReturnedEnumValues[enumValue] += 1
+	}
 	fieldMetrics.ErrorCount += conversion.Bool2Int(msg.Error != nil)
 	fieldMetrics.Count += 1
-	fieldMetrics.ReturnType = msg.ReturnType
+	fieldMetrics.ReturnType = returnType
 }
 
 func (a *Aggregator) processOperation(msg *OperationMessage) {
 	// Find operations metrics
-	metrics := a.metrics.FindContextMetrics(msg.Client.Name, msg.Client.Version, a.serverVersion)
-	operationMetrics := metrics.FindOperationMetrics(msg.Hash)
+	metrics := a.metrics.FindContextMetrics(a.metricsContext(msg.Client))
+	if msg.Rejection != "" && msg.Hash == "" {
+		if metrics.Rejections == nil {
+			metrics.Rejections = make(map[string]int32)
+		}
+		metrics.Rejections[msg.Rejection] += 1
+		return
+	}
+	hash := a.operationHash(msg.Hash)
+	if msg.ErrorKind != "" {
+		a.processInvalidOperation(metrics, hash, msg)
+		return
+	}
+	operationMetrics := metrics.FindOperationMetrics(hash)
+	if msg.Rejection != "" {
+		if operationMetrics.Rejections == nil {
+			operationMetrics.Rejections = make(map[string]int32)
+		}
+		operationMetrics.Rejections[msg.Rejection] += 1
+	}
+	if msg.Payloads > 0 {
+		a.processIncrementalOperation(operationMetrics, msg)
+		return
+	}
 
 	// Insert message
 	err := operationMetrics.Histogram.Add(float64(msg.Duration))
@@ -130,28 +218,204 @@ func (a *Aggregator) processOperation(msg *OperationMessage) {
 		})
 		return
 	}
+	_ = operationMetrics.ResponseSizeHistogram.Add(float64(msg.ResponseSize))
+	if msg.Complexity > 0 {
+		_ = operationMetrics.ComplexityHistogram.Add(float64(msg.Complexity))
+	}
 	operationMetrics.ErrorCount += conversion.Bool2Int(msg.HasErrors)
 	operationMetrics.Count += 1
 
+	// Insert tenant usage
+	if msg.Tenant != "" {
+		a.processTenant(hash, msg)
+	}
+
+	// Insert schema usage
+	processUsage(metrics, msg.Usage.Merge(msg.VariablesUsage))
+
 	// Insert definition
-	if !a.knownOperations[msg.Hash] {
-		a.definitions.Operations = append(a.definitions.Operations, models.OperationDefinition{
-			Name:      msg.Name,
-			Type:      msg.Type,
-			Hash:      msg.Hash,
-			Signature: msg.Signature,
+	a.insertDefinition(hash, models.OperationDefinition{
+		Name:       msg.Name,
+		Type:       msg.Type,
+		Signature:  msg.Signature,
+		Depth:      msg.Stats.Depth,
+		Fields:     msg.Stats.Fields,
+		Aliases:    msg.Stats.Aliases,
+		Fragments:  msg.Stats.Fragments,
+		Complexity: msg.Complexity,
+
+		Arguments:   msg.Usage.Arguments,
+		InputFields: msg.Usage.InputFields,
+		EnumValues:  msg.Usage.EnumValues,
+	})
+}
+
+func (a *Aggregator) processSubscription(msg *SubscriptionMessage) {
+	// Find subscription metrics
+	metrics := a.metrics.FindContextMetrics(a.metricsContext(msg.Client))
+	hash := a.operationHash(msg.Hash)
+	subscriptionMetrics := metrics.FindSubscriptionMetrics(hash)
+
+	// Insert message
+	histogram := subscriptionMetrics.EventLatencyHistogram
+	if msg.Ended {
+		histogram = subscriptionMetrics.LifetimeHistogram
+	}
+	err := histogram.Add(float64(msg.Duration))
+	if err != nil {
+		a.logger.Error("unable to insert subscription duration", map[string]interface{}{
+			"error":     err,
+			"duration":  msg.Duration,
+			"operation": msg.Name,
 		})
-		a.knownOperations[msg.Hash] = true
+		return
 	}
+	if msg.Ended {
+		subscriptionMetrics.Count += 1
+		processUsage(metrics, msg.Usage.Merge(msg.VariablesUsage))
+	} else {
+		subscriptionMetrics.EventErrorCount += conversion.Bool2Int(msg.HasErrors)
+		subscriptionMetrics.EventCount += 1
+	}
+
+	// Insert definition
+	a.insertDefinition(hash, models.OperationDefinition{
+		Name:      msg.Name,
+		Type:      "subscription",
+		Signature: msg.Signature,
+		Depth:     msg.Stats.Depth,
+		Fields:    msg.Stats.Fields,
+		Aliases:   msg.Stats.Aliases,
+		Fragments: msg.Stats.Fragments,
+
+		Arguments:   msg.Usage.Arguments,
+		InputFields: msg.Usage.InputFields,
+		EnumValues:  msg.Usage.EnumValues,
+	})
+}
+
+// metricsContext builds the context of the client, the values over the limits are replaced by __other__
+func (a *Aggregator) metricsContext(details client.Details) models.MetricsContext {
+	if a.clientNormalizer != nil {
+		details = a.clientNormalizer(details)
+	}
+	context := models.MetricsContext{
+		ClientName:    details.Name,
+		ClientVersion: details.Version,
+		ServerVersion: a.serverVersion,
+	}
+	if len(details.Dimensions) > 0 {
+		context.Dimensions = make(map[string]string, len(details.Dimensions))
+		for dimension, value := range details.Dimensions {
+			if !a.dimensions.allow(dimension) {
+				context.Dimensions[otherValue] = otherValue
+				continue
+			}
+			values, ok := a.dimensionValues[dimension]
+			if !ok {
+				values = newLimiter(a.maxDimensionValues)
+				a.dimensionValues[dimension] = values
+			}
+			if !values.allow(value) {
+				value = otherValue
+			}
+			context.Dimensions[dimension] = value
+		}
+	}
+	if !a.contexts.allow(context.Key()) {
+		a.metrics.Overflow.Contexts += 1
+		return models.MetricsContext{
+			ClientName:    otherValue,
+			ClientVersion: otherValue,
+			ServerVersion: a.serverVersion,
+		}
+	}
+	return context
+}
+
+// operationHash returns the hash of the operation or __other__ if over the limit
+func (a *Aggregator) operationHash(hash string) string {
+	if !a.operations.allow(hash) {
+		a.metrics.Overflow.Operations += 1
+		return otherValue
+	}
+	return hash
+}
+
+func (a *Aggregator) insertDefinition(hash string, definition models.OperationDefinition) {
+	if hash != otherValue && !a.knownOperations[hash] {
+		definition.Hash = hash
+		a.definitions.Operations = append(a.definitions.Operations, definition)
+		a.knownOperations[hash] = true
+	}
+}
+
+func (a *Aggregator) processIncrementalOperation(operationMetrics *models.OperationMetrics, msg *OperationMessage) {
+	err := operationMetrics.TotalHistogram.Add(float64(msg.Duration))
+	if err != nil {
+		a.logger.Error("unable to insert operation total duration", map[string]interface{}{
+			"error":     err,
+			"duration":  msg.Duration,
+			"operation": msg.Name,
+		})
+		return
+	}
+	operationMetrics.ErrorCount += conversion.Bool2Int(msg.HasErrors)
+	operationMetrics.IncrementalCount += 1
+	operationMetrics.PayloadCount += int32(msg.Payloads)
+}
+
+// processUsage counts the schema coordinates referenced by a request
+func processUsage(metrics *models.ContextualizedUsageMetrics, usage signature.Usage) {
+	for _, argument := range usage.Arguments {
+		metrics.Arguments[argument] += 1
+	}
+	for _, inputField := range usage.InputFields {
+		metrics.InputFields[inputField] += 1
+	}
+	for _, enumValue := range usage.EnumValues {
+		metrics.EnumValues[enumValue] += 1
+	}
+}
+
+func (a *Aggregator) processTenant(hash string, msg *OperationMessage) {
+	tenantMetrics := a.metrics.FindTenantMetrics(msg.Tenant, a.maxTenants)
+	tenantMetrics.Count += 1
+	tenantMetrics.ErrorCount += conversion.Bool2Int(msg.HasErrors)
+	tenantMetrics.ResolverTime += msg.ResolverTime
+
+	operationMetrics := tenantMetrics.FindOperationMetrics(hash)
+	operationMetrics.Count += 1
+	operationMetrics.ErrorCount += conversion.Bool2Int(msg.HasErrors)
+	operationMetrics.ResolverTime += msg.ResolverTime
+}
+
+func (a *Aggregator) processInvalidOperation(metrics *models.ContextualizedUsageMetrics, hash string, msg *OperationMessage) {
+	invalidMetrics := metrics.FindInvalidOperationMetrics(hash)
+	invalidMetrics.Kind = msg.ErrorKind
+	invalidMetrics.Sample = msg.Signature
+	invalidMetrics.Count += 1
 }
 
 func (a *Aggregator) flush() {
 	now := time.Now() // We prefer end time as the TS
 	if len(a.metrics.Metrics) > 0 {
 		metrics := a.metrics
-		a.metrics = models.NewUsageMetrics()
+		a.metrics = models.NewUsageMetricsWithSketches(a.sketches)
+		a.dimensions.reset()
+		a.dimensionValues = make(map[string]*limiter)
+		a.contexts.reset()
+		a.operations.reset()
+		a.fields.reset()
 		metrics.Timestamp = now
-		a.sender.SendMetrics(metrics)
+		a.checkClients(metrics)
+		if a.tenantExporter != nil && len(metrics.Tenants) > 0 {
+			a.tenantExporter(now, metrics.Tenants)
+		}
+		if a.usageExporter != nil {
+			a.usageExporter(now, schemaUsage(metrics))
+		}
+		a.sendMetrics(metrics)
 	}
 	if len(a.definitions.Operations) > 0 {
 		definitions := a.definitions
@@ -160,3 +424,36 @@ func (a *Aggregator) flush() {
 		a.sender.SendDefinitions(definitions)
 	}
 }
+
+// sendMetrics encodes the metrics once, they are only split by context if the payload is over the max size
+func (a *Aggregator) sendMetrics(metrics *models.UsageMetrics) {
+	p := a.sender.encode(metrics)
+	if len(metrics.Metrics) < 2 || len(p.body) <= a.maxPayloadSize {
+		a.sender.sendEncodedMetrics(metrics, p)
+		return
+	}
+	reports := metrics.Split(a.maxPayloadSize, func(m *models.ContextualizedUsageMetrics) int {
+		return len(a.sender.encode(m).body)
+	})
+	a.logger.Debug("splitting metrics in multiple reports", map[string]interface{}{
+		"reports": len(reports),
+		"size":    len(p.body),
+	})
+	for _, r := range reports {
+		a.sender.SendMetrics(r)
+	}
+}
+
+// checkClients warns once if no client details were extracted during the first interval
+func (a *Aggregator) checkClients(metrics *models.UsageMetrics) {
+	if a.clientsChecked {
+		return
+	}
+	a.clientsChecked = true
+	for _, m := range metrics.Metrics {
+		if m.Context.ClientName != "" || m.Context.ClientVersion != "" {
+			return
+		}
+	}
+	a.logger.Warn("graphmetrics could not extract any client details, check the client extractor configuration", nil)
+}
@@ -0,0 +1,256 @@
+package graphmetrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphmetrics/graphmetrics-go/client"
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+func TestAggregator_DimensionsOverflow(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{
+		Advanced: &AdvancedConfiguration{MaxDimensionValues: 2},
+	})
+
+	for _, tenant := range []string{"a", "b", "c", "a"} {
+		aggregator.processField(&FieldMessage{
+			TypeName:  "Query",
+			FieldName: "field",
+			Duration:  time.Millisecond,
+			Client:    client.Details{Name: "web", Dimensions: map[string]string{"tenant": tenant}},
+		})
+	}
+
+	counts := map[string]int32{}
+	for _, m := range aggregator.metrics.Metrics {
+		counts[m.Context.Dimensions["tenant"]] = m.Types["Query"].Fields["field"].Count
+	}
+	assert.Equal(t, map[string]int32{"a": 2, "b": 1, otherValue: 1}, counts)
+}
+
+func TestAggregator_DimensionKeysOverflow(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{
+		Advanced: &AdvancedConfiguration{MaxDimensions: 1},
+	})
+
+	for _, dimensions := range []map[string]string{{"tenant": "a"}, {"region": "eu"}, {"tenant": "b", "platform": "ios"}} {
+		aggregator.processField(&FieldMessage{
+			TypeName:  "Query",
+			FieldName: "field",
+			Duration:  time.Millisecond,
+			Client:    client.Details{Name: "web", Dimensions: dimensions},
+		})
+	}
+
+	var contexts []map[string]string
+	for _, m := range aggregator.metrics.Metrics {
+		contexts = append(contexts, m.Context.Dimensions)
+	}
+	assert.ElementsMatch(t, []map[string]string{
+		{"tenant": "a"},
+		{otherValue: otherValue},
+		{"tenant": "b", otherValue: otherValue},
+	}, contexts)
+}
+
+func TestAggregator_OperationsOverflow(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{
+		Advanced: &AdvancedConfiguration{MaxOperations: 1},
+	})
+
+	for _, hash := range []string{"a", "b", "c", "a"} {
+		aggregator.processOperation(&OperationMessage{
+			Hash:     hash,
+			Duration: time.Millisecond,
+		})
+	}
+
+	operations := aggregator.metrics.Metrics[0].Operations
+	assert.Len(t, operations, 2)
+	assert.Equal(t, int32(2), operations["a"].Count)
+	assert.Equal(t, int32(2), operations[otherValue].Count)
+	assert.Equal(t, int32(2), aggregator.metrics.Overflow.Operations)
+	assert.Len(t, aggregator.definitions.Operations, 1)
+}
+
+func TestAggregator_ClientNormalizer(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{
+		ClientNormalizer: client.ChainNormalizers(client.AllowNames("ios"), client.CollapsePatchVersion),
+	})
+
+	for _, details := range []client.Details{{Name: "ios", Version: "5.2.1"}, {Name: "ios", Version: "5.2.3"}, {Name: "random", Version: "1"}} {
+		aggregator.processOperation(&OperationMessage{
+			Hash:     "a",
+			Duration: time.Millisecond,
+			Client:   details,
+		})
+	}
+
+	contexts := map[string]int32{}
+	for _, m := range aggregator.metrics.Metrics {
+		contexts[m.Context.ClientName+"@"+m.Context.ClientVersion] = m.Operations["a"].Count
+	}
+	assert.Equal(t, map[string]int32{"ios@5.2": 2, otherValue + "@" + otherValue: 1}, contexts)
+}
+
+func TestAggregator_FieldDurationStats(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{})
+
+	for _, duration := range []time.Duration{3 * time.Millisecond, time.Millisecond, 2 * time.Millisecond} {
+		aggregator.processField(&FieldMessage{
+			TypeName:  "Query",
+			FieldName: "field",
+			Duration:  duration,
+		})
+	}
+
+	histogram := aggregator.metrics.Metrics[0].Types["Query"].Fields["field"].Histogram
+	assert.Equal(t, float64(6*time.Millisecond), histogram.Sum)
+	assert.Equal(t, float64(time.Millisecond), histogram.Min)
+	assert.Equal(t, float64(3*time.Millisecond), histogram.Max)
+	assert.Equal(t, int32(3), histogram.Count())
+}
+
+func TestAggregator_ListLengthAndResponseSize(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{})
+
+	for _, length := range []int{0, 10, 1000} {
+		aggregator.processField(&FieldMessage{
+			TypeName:   "Query",
+			FieldName:  "list",
+			Duration:   time.Millisecond,
+			List:       true,
+			ListLength: length,
+		})
+	}
+	aggregator.processField(&FieldMessage{TypeName: "Query", FieldName: "field", Duration: time.Millisecond})
+	aggregator.processOperation(&OperationMessage{Hash: "a", Duration: time.Millisecond, ResponseSize: 2048})
+
+	metrics := aggregator.metrics.Metrics[0]
+	list := metrics.Types["Query"].Fields["list"].ListLengthHistogram
+	assert.Equal(t, int32(3), list.Count())
+	assert.Equal(t, int32(1), list.ZeroCount)
+	assert.Equal(t, float64(1010), list.Sum)
+	assert.Nil(t, metrics.Types["Query"].Fields["field"].ListLengthHistogram)
+	assert.Equal(t, float64(2048), metrics.Operations["a"].ResponseSizeHistogram.Max)
+}
+
+func TestAggregator_OperationDefinitionStats(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{})
+
+	for _, complexity := range []int{12, 40} {
+		aggregator.processOperation(&OperationMessage{
+			Hash:       "a",
+			Duration:   time.Millisecond,
+			Stats:      signature.Stats{Depth: 3, Fields: 7, Aliases: 1, Fragments: 2},
+			Complexity: complexity,
+		})
+	}
+
+	assert.Len(t, aggregator.definitions.Operations, 1)
+	definition := aggregator.definitions.Operations[0]
+	assert.Equal(t, "a", definition.Hash)
+	assert.Equal(t, 3, definition.Depth)
+	assert.Equal(t, 7, definition.Fields)
+	assert.Equal(t, 12, definition.Complexity)
+	complexity := aggregator.metrics.Metrics[0].Operations["a"].ComplexityHistogram
+	assert.Equal(t, int32(2), complexity.Count())
+	assert.Equal(t, float64(40), complexity.Max)
+}
+
+func TestAggregator_SchemaUsagePerClient(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{})
+	usage := signature.Usage{Arguments: []string{"Query.field(id)"}, EnumValues: []string{"Color.RED"}}
+
+	for _, name := range []string{"ios", "ios", "web"} {
+		aggregator.processOperation(&OperationMessage{
+			Hash:     "a",
+			Duration: time.Millisecond,
+			Client:   client.Details{Name: name},
+			Usage:    usage,
+		})
+	}
+
+	counts := map[string]int32{}
+	for _, m := range aggregator.metrics.Metrics {
+		counts[m.Context.ClientName] = m.Arguments["Query.field(id)"]
+		assert.Equal(t, m.Arguments["Query.field(id)"], m.EnumValues["Color.RED"])
+	}
+	assert.Equal(t, map[string]int32{"ios": 2, "web": 1}, counts)
+	assert.Equal(t, usage.Arguments, aggregator.definitions.Operations[0].Arguments)
+}
+
+func TestAggregator_EnumValuesSentAndReturned(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{})
+
+	aggregator.processOperation(&OperationMessage{
+		Hash:           "a",
+		Duration:       time.Millisecond,
+		Usage:          signature.Usage{EnumValues: []string{"Color.RED"}},
+		VariablesUsage: signature.Usage{EnumValues: []string{"Color.GREEN", "Color.RED"}},
+	})
+	aggregator.processField(&FieldMessage{
+		TypeName:   "Query",
+		FieldName:  "colors",
+		Duration:   time.Millisecond,
+		EnumValues: []string{"Color.BLUE"},
+	})
+
+	metrics := aggregator.metrics.Metrics[0]
+	assert.Equal(t, map[string]int32{"Color.GREEN": 1, "Color.RED": 1}, metrics.EnumValues)
+	assert.Equal(t, map[string]int32{"Color.BLUE": 1}, metrics.ReturnedEnumValues)
+	assert.Equal(t, []string{"Color.RED"}, aggregator.definitions.Operations[0].EnumValues)
+}
+
+func TestAggregator_RejectedOperations(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{})
+
+	aggregator.processOperation(&OperationMessage{
+		Name:       "MyQuery",
+		Hash:       "a",
+		Signature:  "query MyQuery {\n\tfield\n}\n",
+		HasErrors:  true,
+		Rejection:  "complexity_limit",
+		Duration:   time.Millisecond,
+		Complexity: 500,
+	})
+	aggregator.processOperation(&OperationMessage{HasErrors: true, Rejection: "persisted_query_not_found"})
+
+	metrics := aggregator.metrics.Metrics[0]
+	assert.Empty(t, metrics.InvalidOperations)
+	assert.Equal(t, int32(1), metrics.Operations["a"].ErrorCount)
+	assert.Equal(t, map[string]int32{"complexity_limit": 1}, metrics.Operations["a"].Rejections)
+	assert.Equal(t, map[string]int32{"persisted_query_not_found": 1}, metrics.Rejections)
+	assert.Len(t, metrics.Operations, 1)
+	assert.Equal(t, "query MyQuery {\n\tfield\n}\n", aggregator.definitions.Operations[0].Signature)
+}
+
+func TestAggregator_SplitOverMaxPayloadSize(t *testing.T) {
+	for maxPayloadSize, reports := range map[int]int{1 << 20: 1, 10: 2} {
+		var dump bytes.Buffer
+		aggregator := NewAggregator(&Configuration{
+			Advanced: &AdvancedConfiguration{
+				MaxPayloadSize: maxPayloadSize,
+				DumpWriter:     &dump,
+				DumpFormat:     NDJSONDump,
+				DryRun:         true,
+			},
+		})
+		for _, name := range []string{"web", "ios"} {
+			aggregator.processField(&FieldMessage{
+				TypeName:  "Query",
+				FieldName: "field",
+				Duration:  time.Millisecond,
+				Client:    client.Details{Name: name},
+			})
+		}
+
+		aggregator.sendMetrics(aggregator.metrics)
+		assert.Equal(t, reports, strings.Count(dump.String(), "\n"), maxPayloadSize)
+	}
+}
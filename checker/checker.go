@@ -0,0 +1,372 @@
+package checker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type Severity string
+
+const (
+	Safe      Severity = "safe"
+	Dangerous Severity = "dangerous" // Might change the behaviour of the clients
+	Breaking  Severity = "breaking"  // Fails the requests of the clients still using it
+)
+
+// Change is a difference between two schemas classified with the usage of the clients
+type Change struct {
+	Severity    Severity      `json:"severity"`
+	Coordinate  string        `json:"coordinate"`
+	Description string        `json:"description"`
+	Clients     []ClientCount `json:"clients,omitempty"` // Clients still using the coordinate, most used first
+}
+
+type ClientCount struct {
+	Client string `json:"client"`
+	Count  int64  `json:"count"`
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%-9s %s", c.Severity, c.Description)
+	for i, client := range c.Clients {
+		if i == 0 {
+			s += ", used by "
+		} else {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s (%d requests)", client.Client, client.Count)
+	}
+	return s
+}
+
+// Check loads the schemas and classifies their differences with the usage
+func Check(oldSDL string, newSDL string, usage *Usage) ([]Change, error) {
+	oldSchema, err := loadSchema("old", oldSDL)
+	if err != nil {
+		return nil, err
+	}
+	newSchema, err := loadSchema("new", newSDL)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(oldSchema, newSchema, usage), nil
+}
+
+func loadSchema(name string, sdl string) (*ast.Schema, error) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: name, Input: sdl})
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %s schema: %w", name, err)
+	}
+	return schema, nil
+}
+
+// Compare classifies the differences between two loaded schemas with the usage.
+// A change of a coordinate unused over the window is safe, unless it depends on the fields usage
+// and some fields overflowed the SDK limit: it is then dangerous. The changes are sorted by severity.
+func Compare(oldSchema *ast.Schema, newSchema *ast.Schema, usage *Usage) []Change {
+	c := &comparison{schema: oldSchema, usage: usage}
+	for name, oldType := range oldSchema.Types {
+		if oldType.BuiltIn {
+			continue
+		}
+		newType := newSchema.Types[name]
+		if newType == nil || newType.Kind != oldType.Kind {
+			c.typeRemoved(oldType)
+			continue
+		}
+		switch oldType.Kind {
+		case ast.Object:
+			c.compareFields(oldType, newType)
+			c.compareInterfaces(oldType, newType)
+		case ast.Interface:
+			c.compareFields(oldType, newType)
+		case ast.Union:
+			c.compareUnionMembers(oldType, newType)
+		case ast.InputObject:
+			c.compareInputFields(oldType, newType)
+		case ast.Enum:
+			c.compareEnumValues(oldType, newType)
+		}
+	}
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		if severityOrder[c.changes[i].Severity] != severityOrder[c.changes[j].Severity] {
+			return severityOrder[c.changes[i].Severity] < severityOrder[c.changes[j].Severity]
+		}
+		return c.changes[i].Coordinate < c.changes[j].Coordinate
+	})
+	return c.changes
+}
+
+// HasBreaking returns true if one of the changes is breaking
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Severity == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+var severityOrder = map[Severity]int{Breaking: 0, Dangerous: 1, Safe: 2}
+
+type comparison struct {
+	schema  *ast.Schema // Old schema, the usage was recorded against it
+	usage   *Usage
+	changes []Change
+}
+
+// add classifies the change as breaking (or dangerous) if some clients use it, safe otherwise
+func (c *comparison) add(severity Severity, coordinate string, description string, clients []ClientCount) {
+	if len(clients) == 0 {
+		severity = Safe
+	}
+	c.changes = append(c.changes, Change{
+		Severity:    severity,
+		Coordinate:  coordinate,
+		Description: description,
+		Clients:     clients,
+	})
+}
+
+// addFieldsChange classifies a change with the fields usage, an unused coordinate is only dangerous
+// if the usage of some fields is unknown
+func (c *comparison) addFieldsChange(severity Severity, coordinate string, description string, clients []ClientCount) {
+	if len(clients) == 0 && severity != Safe && c.usage.fieldsOverflowed() {
+		c.changes = append(c.changes, Change{
+			Severity:    Dangerous,
+			Coordinate:  coordinate,
+			Description: description + ", unused but some fields usage overflowed",
+		})
+		return
+	}
+	c.add(severity, coordinate, description, clients)
+}
+
+func (c *comparison) typeRemoved(oldType *ast.Definition) {
+	description := fmt.Sprintf("type %s removed", oldType.Name)
+	var clients []ClientCount
+	switch oldType.Kind {
+	case ast.Object, ast.Interface:
+		c.addFieldsChange(Breaking, oldType.Name, description, c.typeClients(oldType))
+		return
+	case ast.Union:
+		c.addFieldsChange(Breaking, oldType.Name, description, c.membersClients(oldType.Types))
+		return
+	case ast.InputObject:
+		clients = c.usage.clientsUsingPrefix(inputFields, oldType.Name+".")
+	case ast.Enum:
+		clients = mergeClients(
+			c.usage.clientsUsingPrefix(enumValues, oldType.Name+"."),
+			c.usage.clientsUsingPrefix(returnedEnumValues, oldType.Name+"."),
+		)
+	}
+	c.add(Breaking, oldType.Name, description, clients)
+}
+
+// compareInterfaces reports the interfaces no longer implemented, the fragments on the interface
+// within the object (or on the object within the interface) fail for the clients using the object
+func (c *comparison) compareInterfaces(oldType *ast.Definition, newType *ast.Definition) {
+	for _, name := range oldType.Interfaces {
+		if !contains(newType.Interfaces, name) {
+			description := fmt.Sprintf("type %s no longer implements %s", oldType.Name, name)
+			c.addFieldsChange(Breaking, oldType.Name, description, c.usage.clientsUsingPrefix(fields, oldType.Name+"."))
+		}
+	}
+}
+
+// compareUnionMembers classifies the members with the usage of their fields, the clients selecting
+// a removed member fail while the clients of the union might not handle an added member
+func (c *comparison) compareUnionMembers(oldType *ast.Definition, newType *ast.Definition) {
+	for _, member := range oldType.Types {
+		if !contains(newType.Types, member) {
+			coordinate := oldType.Name + "." + member
+			description := fmt.Sprintf("member %s removed from union %s", member, oldType.Name)
+			c.addFieldsChange(Breaking, coordinate, description, c.usage.clientsUsingPrefix(fields, member+"."))
+		}
+	}
+	for _, member := range newType.Types {
+		if !contains(oldType.Types, member) {
+			coordinate := newType.Name + "." + member
+			description := fmt.Sprintf("member %s added to union %s", member, newType.Name)
+			c.addFieldsChange(Dangerous, coordinate, description, c.membersClients(oldType.Types))
+		}
+	}
+}
+
+// typeClients returns the clients using the fields of the type, the fields of an interface are
+// resolved (and recorded) on the objects implementing it
+func (c *comparison) typeClients(t *ast.Definition) []ClientCount {
+	clients := c.usage.clientsUsingPrefix(fields, t.Name+".")
+	if t.Kind == ast.Interface {
+		for _, impl := range c.schema.PossibleTypes[t.Name] {
+			clients = mergeClients(clients, c.usage.clientsUsingPrefix(fields, impl.Name+"."))
+		}
+	}
+	return clients
+}
+
+// fieldClients returns the clients using the field of the type, including through its implementations
+func (c *comparison) fieldClients(t *ast.Definition, field string) []ClientCount {
+	clients := c.usage.clientsUsing(fields, t.Name+"."+field)
+	if t.Kind == ast.Interface {
+		for _, impl := range c.schema.PossibleTypes[t.Name] {
+			clients = mergeClients(clients, c.usage.clientsUsing(fields, impl.Name+"."+field))
+		}
+	}
+	return clients
+}
+
+// membersClients returns the clients using the fields of any of the types
+func (c *comparison) membersClients(types []string) []ClientCount {
+	var clients []ClientCount
+	for _, t := range types {
+		clients = mergeClients(clients, c.usage.clientsUsingPrefix(fields, t+"."))
+	}
+	return clients
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *comparison) compareFields(oldType *ast.Definition, newType *ast.Definition) {
+	for _, oldField := range oldType.Fields {
+		if strings.HasPrefix(oldField.Name, "__") {
+			continue
+		}
+		coordinate := oldType.Name + "." + oldField.Name
+		newField := newType.Fields.ForName(oldField.Name)
+		if newField == nil {
+			c.addFieldsChange(Breaking, coordinate, fmt.Sprintf("field %s removed", coordinate), c.fieldClients(oldType, oldField.Name))
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			severity := Breaking
+			if isSafeOutputChange(oldField.Type, newField.Type) {
+				severity = Safe
+			}
+			c.addFieldsChange(severity, coordinate, fmt.Sprintf("field %s type changed from %s to %s", coordinate, oldField.Type, newField.Type), c.fieldClients(oldType, oldField.Name))
+		}
+		c.compareArguments(oldType, oldField, newField)
+	}
+}
+
+func (c *comparison) compareArguments(oldType *ast.Definition, oldField *ast.FieldDefinition, newField *ast.FieldDefinition) {
+	field := oldType.Name + "." + oldField.Name
+	for _, oldArg := range oldField.Arguments {
+		coordinate := field + "(" + oldArg.Name + ")"
+		newArg := newField.Arguments.ForName(oldArg.Name)
+		if newArg == nil {
+			c.add(Breaking, coordinate, fmt.Sprintf("argument %s removed", coordinate), c.usage.clientsUsing(arguments, coordinate))
+			continue
+		}
+		if oldArg.Type.String() != newArg.Type.String() {
+			severity := Breaking
+			if isSafeInputChange(oldArg.Type, newArg.Type) {
+				severity = Safe
+			}
+			c.add(severity, coordinate, fmt.Sprintf("argument %s type changed from %s to %s", coordinate, oldArg.Type, newArg.Type), c.usage.clientsUsing(arguments, coordinate))
+		}
+	}
+	for _, newArg := range newField.Arguments {
+		if oldField.Arguments.ForName(newArg.Name) == nil && isRequired(newArg.Type, newArg.DefaultValue) {
+			coordinate := field + "(" + newArg.Name + ")"
+			c.addFieldsChange(Breaking, coordinate, fmt.Sprintf("required argument %s added", coordinate), c.fieldClients(oldType, oldField.Name))
+		}
+	}
+}
+
+func (c *comparison) compareInputFields(oldType *ast.Definition, newType *ast.Definition) {
+	for _, oldField := range oldType.Fields {
+		coordinate := oldType.Name + "." + oldField.Name
+		newField := newType.Fields.ForName(oldField.Name)
+		if newField == nil {
+			c.add(Breaking, coordinate, fmt.Sprintf("input field %s removed", coordinate), c.usage.clientsUsing(inputFields, coordinate))
+			continue
+		}
+		if oldField.Type.String() != newField.Type.String() {
+			severity := Breaking
+			if isSafeInputChange(oldField.Type, newField.Type) {
+				severity = Safe
+			}
+			c.add(severity, coordinate, fmt.Sprintf("input field %s type changed from %s to %s", coordinate, oldField.Type, newField.Type), c.usage.clientsUsing(inputFields, coordinate))
+		}
+	}
+	for _, newField := range newType.Fields {
+		if oldType.Fields.ForName(newField.Name) == nil && isRequired(newField.Type, newField.DefaultValue) {
+			coordinate := newType.Name + "." + newField.Name
+			c.add(Breaking, coordinate, fmt.Sprintf("required input field %s added", coordinate), c.usage.clientsUsingPrefix(inputFields, newType.Name+"."))
+		}
+	}
+}
+
+func (c *comparison) compareEnumValues(oldType *ast.Definition, newType *ast.Definition) {
+	for _, oldValue := range oldType.EnumValues {
+		coordinate := oldType.Name + "." + oldValue.Name
+		if newType.EnumValues.ForName(oldValue.Name) == nil {
+			clients := mergeClients(c.usage.clientsUsing(enumValues, coordinate), c.usage.clientsUsing(returnedEnumValues, coordinate))
+			c.add(Breaking, coordinate, fmt.Sprintf("enum value %s removed", coordinate), clients)
+		}
+	}
+	for _, newValue := range newType.EnumValues {
+		if oldType.EnumValues.ForName(newValue.Name) == nil {
+			// Clients receiving the enum might not handle the new value
+			coordinate := newType.Name + "." + newValue.Name
+			c.add(Dangerous, coordinate, fmt.Sprintf("enum value %s added", coordinate), c.usage.clientsUsingPrefix(returnedEnumValues, newType.Name+"."))
+		}
+	}
+}
+
+func isRequired(t *ast.Type, defaultValue *ast.Value) bool {
+	return t.NonNull && defaultValue == nil
+}
+
+// isSafeOutputChange is true if the output type is only made stricter (nullable to non null)
+func isSafeOutputChange(oldType *ast.Type, newType *ast.Type) bool {
+	if oldType.NamedType != newType.NamedType || (oldType.Elem == nil) != (newType.Elem == nil) {
+		return false
+	}
+	if oldType.NonNull && !newType.NonNull {
+		return false
+	}
+	if oldType.Elem != nil {
+		return isSafeOutputChange(oldType.Elem, newType.Elem)
+	}
+	return true
+}
+
+// isSafeInputChange is true if the input type is only relaxed (non null to nullable)
+func isSafeInputChange(oldType *ast.Type, newType *ast.Type) bool {
+	return isSafeOutputChange(newType, oldType)
+}
+
+func mergeClients(a []ClientCount, b []ClientCount) []ClientCount {
+	counts := map[string]int64{}
+	for _, c := range append(a, b...) {
+		counts[c.Client] += c.Count
+	}
+	var clients []ClientCount
+	for client, count := range counts {
+		clients = append(clients, ClientCount{Client: client, Count: count})
+	}
+	sortClients(clients)
+	return clients
+}
+
+func sortClients(clients []ClientCount) {
+	sort.Slice(clients, func(i, j int) bool {
+		if clients[i].Count != clients[j].Count {
+			return clients[i].Count > clients[j].Count
+		}
+		return clients[i].Client < clients[j].Client
+	})
+}
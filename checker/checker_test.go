@@ -0,0 +1,211 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const oldSchema = `
+enum Color {
+	RED
+	GREEN
+}
+
+input Filter {
+	name: String
+	color: Color
+}
+
+type Item {
+	id: ID!
+	name: String
+	legacy: String
+}
+
+type Query {
+	items(filter: Filter, first: Int, legacyArg: String): [Item]
+	color: Color
+	unused: String
+}
+`
+
+const newSchema = `
+enum Color {
+	RED
+	BLUE
+}
+
+input Filter {
+	name: String
+}
+
+type Item {
+	id: ID!
+	name: String!
+}
+
+type Query {
+	items(filter: Filter, first: Int!, other: String): [Item]
+	color: Color
+}
+`
+
+func givenUsage() *Usage {
+	usage := NewUsage()
+	ios := usage.FindClientUsage("ios 5.2")
+	ios.Fields["Item.legacy"] = 120
+	ios.Fields["Query.items"] = 300
+	ios.Arguments["Query.items(first)"] = 10
+	ios.ReturnedEnumValues["Color.GREEN"] = 3
+	web := usage.FindClientUsage("web 1.0")
+	web.Fields["Query.items"] = 50
+	web.InputFields["Filter.name"] = 50
+	return usage
+}
+
+func TestChecker_Check(t *testing.T) {
+	changes, err := Check(oldSchema, newSchema, givenUsage())
+	assert.NoError(t, err)
+
+	severities := map[string]Severity{}
+	for _, c := range changes {
+		severities[c.Coordinate] = c.Severity
+	}
+	assert.Equal(t, map[string]Severity{
+		"Item.legacy":            Breaking,
+		"Query.items(first)":     Breaking,
+		"Color.GREEN":            Breaking,
+		"Color.BLUE":             Dangerous,
+		"Filter.color":           Safe,
+		"Item.name":              Safe,
+		"Query.items(legacyArg)": Safe,
+		"Query.unused":           Safe,
+	}, severities)
+	assert.True(t, HasBreaking(changes))
+	assert.Equal(t, Breaking, changes[0].Severity)
+}
+
+func TestChecker_ChangeString(t *testing.T) {
+	changes, _ := Check(oldSchema, newSchema, givenUsage())
+	for _, c := range changes {
+		if c.Coordinate == "Item.legacy" {
+			assert.Equal(t, "breaking  field Item.legacy removed, used by ios 5.2 (120 requests)", c.String())
+		}
+	}
+}
+
+func TestChecker_RequiredArgumentAdded(t *testing.T) {
+	changes, err := Check(`type Query { items: [String] }`, `type Query { items(first: Int!): [String] }`, givenUsage())
+	assert.NoError(t, err)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, Breaking, changes[0].Severity)
+	assert.Equal(t, []ClientCount{{Client: "ios 5.2", Count: 300}, {Client: "web 1.0", Count: 50}}, changes[0].Clients)
+}
+
+func TestChecker_InvalidSchema(t *testing.T) {
+	_, err := Check(`type Query {`, newSchema, NewUsage())
+	assert.Error(t, err)
+}
+
+func TestChecker_OverflowedFields(t *testing.T) {
+	usage := givenUsage()
+	usage.FindClientUsage("web 1.0").OverflowedFields = 10
+
+	changes, err := Check(oldSchema, newSchema, usage)
+	assert.NoError(t, err)
+
+	for _, c := range changes {
+		switch c.Coordinate {
+		case "Query.unused":
+			assert.Equal(t, Dangerous, c.Severity)
+			assert.Equal(t, "field Query.unused removed, unused but some fields usage overflowed", c.Description)
+		case "Item.name", "Query.items(legacyArg)":
+			assert.Equal(t, Safe, c.Severity, c.Coordinate)
+		}
+	}
+}
+
+func TestChecker_UnionsAndInterfaces(t *testing.T) {
+	oldSDL := `
+interface Node { id: ID! }
+type Book implements Node { id: ID! title: String }
+type Movie implements Node { id: ID! length: Int }
+type Song { id: ID! }
+union Media = Book | Movie | Song
+type Query { media: [Media] node: Node }
+`
+	newSDL := `
+interface Node { id: ID! }
+type Book implements Node { id: ID! title: String }
+type Movie { id: ID! length: Int }
+type Song { id: ID! }
+type Podcast { id: ID! }
+union Media = Book | Song | Podcast
+type Query { media: [Media] node: Node }
+`
+	usage := NewUsage()
+	usage.FindClientUsage("ios 5.2").Fields["Movie.length"] = 7
+	usage.FindClientUsage("web 1.0").Fields["Book.title"] = 3
+
+	changes, err := Check(oldSDL, newSDL, usage)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Change{
+		{
+			Severity:    Breaking,
+			Coordinate:  "Media.Movie",
+			Description: "member Movie removed from union Media",
+			Clients:     []ClientCount{{Client: "ios 5.2", Count: 7}},
+		},
+		{
+			Severity:    Breaking,
+			Coordinate:  "Movie",
+			Description: "type Movie no longer implements Node",
+			Clients:     []ClientCount{{Client: "ios 5.2", Count: 7}},
+		},
+		{
+			Severity:    Dangerous,
+			Coordinate:  "Media.Podcast",
+			Description: "member Podcast added to union Media",
+			Clients:     []ClientCount{{Client: "ios 5.2", Count: 7}, {Client: "web 1.0", Count: 3}},
+		},
+	}, changes)
+}
+
+func TestChecker_InterfaceFieldsUsage(t *testing.T) {
+	oldSDL := `
+interface Node { id: ID! name: String }
+type User implements Node { id: ID! name: String }
+type Group implements Node { id: ID! name: String }
+type Query { node: Node }
+`
+	newSDL := `
+interface Node { id: ID! }
+type User implements Node { id: ID! name: String }
+type Group implements Node { id: ID! name: String }
+type Query { node: Node }
+`
+	usage := NewUsage()
+	usage.FindClientUsage("ios 5.2").Fields["User.name"] = 10
+	usage.FindClientUsage("web 1.0").Fields["Group.name"] = 2
+
+	changes, err := Check(oldSDL, newSDL, usage)
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{
+		Severity:    Breaking,
+		Coordinate:  "Node.name",
+		Description: "field Node.name removed",
+		Clients:     []ClientCount{{Client: "ios 5.2", Count: 10}, {Client: "web 1.0", Count: 2}},
+	}}, changes)
+
+	changes, err = Check(oldSDL, `type Query { user: User } type User { id: ID! name: String } type Group { id: ID! name: String }`, usage)
+	assert.NoError(t, err)
+	assert.Contains(t, changes, Change{
+		Severity:    Breaking,
+		Coordinate:  "Node",
+		Description: "type Node removed",
+		Clients:     []ClientCount{{Client: "ios 5.2", Count: 10}, {Client: "web 1.0", Count: 2}},
+	})
+}
@@ -0,0 +1,129 @@
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Usage is the schema usage per client ("name version"), it is exported by the SDK with a UsageExporter.
+// The exports of a window are merged to decide if a schema change is safe.
+type Usage struct {
+	Clients map[string]*ClientUsage `json:"clients"`
+}
+
+// ClientUsage counts the requests using each schema coordinate
+type ClientUsage struct {
+	Fields             map[string]int64 `json:"fields"`             // Type.field
+	Arguments          map[string]int64 `json:"arguments"`          // Type.field(argument)
+	InputFields        map[string]int64 `json:"inputFields"`        // Input.field
+	EnumValues         map[string]int64 `json:"enumValues"`         // Enum.VALUE sent by the client
+	ReturnedEnumValues map[string]int64 `json:"returnedEnumValues"` // Enum.VALUE returned to the client
+	// Resolutions of the fields over the SDK limit (MaxFields), their coordinates are unknown
+	OverflowedFields int64 `json:"overflowedFields,omitempty"`
+}
+
+func NewUsage() *Usage {
+	return &Usage{Clients: make(map[string]*ClientUsage)}
+}
+
+func newClientUsage() *ClientUsage {
+	return &ClientUsage{
+		Fields:             make(map[string]int64),
+		Arguments:          make(map[string]int64),
+		InputFields:        make(map[string]int64),
+		EnumValues:         make(map[string]int64),
+		ReturnedEnumValues: make(map[string]int64),
+	}
+}
+
+// FindClientUsage returns the usage of a client, it is created if missing
+func (u *Usage) FindClientUsage(client string) *ClientUsage {
+	if v, ok := u.Clients[client]; ok {
+		return v
+	}
+	u.Clients[client] = newClientUsage()
+	return u.Clients[client]
+}
+
+func (u *Usage) Merge(other *Usage) {
+	for client, o := range other.Clients {
+		c := u.FindClientUsage(client)
+		mergeCounts(c.Fields, o.Fields)
+		mergeCounts(c.Arguments, o.Arguments)
+		mergeCounts(c.InputFields, o.InputFields)
+		mergeCounts(c.EnumValues, o.EnumValues)
+		mergeCounts(c.ReturnedEnumValues, o.ReturnedEnumValues)
+		c.OverflowedFields += o.OverflowedFields
+	}
+}
+
+// fieldsOverflowed returns true if some fields usage is unknown, an unused field might then be used
+func (u *Usage) fieldsOverflowed() bool {
+	for _, c := range u.Clients {
+		if c.OverflowedFields > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeCounts(counts map[string]int64, other map[string]int64) {
+	for k, v := range other {
+		counts[k] += v
+	}
+}
+
+// LoadUsage reads a stream of JSON usages (one per export) and merges them
+func LoadUsage(r io.Reader) (*Usage, error) {
+	usage := NewUsage()
+	decoder := json.NewDecoder(r)
+	for {
+		var u Usage
+		err := decoder.Decode(&u)
+		if errors.Is(err, io.EOF) {
+			return usage, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		usage.Merge(&u)
+	}
+}
+
+// clientsUsing returns the clients using the coordinate with their requests count
+func (u *Usage) clientsUsing(counts func(*ClientUsage) map[string]int64, coordinate string) []ClientCount {
+	var clients []ClientCount
+	for client, c := range u.Clients {
+		if n := counts(c)[coordinate]; n > 0 {
+			clients = append(clients, ClientCount{Client: client, Count: n})
+		}
+	}
+	sortClients(clients)
+	return clients
+}
+
+// clientsUsingPrefix returns the clients using any coordinate starting with the prefix
+func (u *Usage) clientsUsingPrefix(counts func(*ClientUsage) map[string]int64, prefix string) []ClientCount {
+	var clients []ClientCount
+	for client, c := range u.Clients {
+		var n int64
+		for coordinate, count := range counts(c) {
+			if strings.HasPrefix(coordinate, prefix) {
+				n += count
+			}
+		}
+		if n > 0 {
+			clients = append(clients, ClientCount{Client: client, Count: n})
+		}
+	}
+	sortClients(clients)
+	return clients
+}
+
+func fields(c *ClientUsage) map[string]int64             { return c.Fields }
+func arguments(c *ClientUsage) map[string]int64          { return c.Arguments }
+func inputFields(c *ClientUsage) map[string]int64        { return c.InputFields }
+func enumValues(c *ClientUsage) map[string]int64         { return c.EnumValues }
+func returnedEnumValues(c *ClientUsage) map[string]int64 { return c.ReturnedEnumValues }
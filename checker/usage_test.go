@@ -0,0 +1,19 @@
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage_LoadUsage(t *testing.T) {
+	stream := `{"clients":{"ios 5.2":{"fields":{"Query.items":2}}}}
+{"clients":{"ios 5.2":{"fields":{"Query.items":3}},"web 1.0":{"enumValues":{"Color.RED":1}}}}`
+
+	usage, err := LoadUsage(strings.NewReader(stream))
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(5), usage.Clients["ios 5.2"].Fields["Query.items"])
+	assert.Equal(t, int64(1), usage.Clients["web 1.0"].EnumValues["Color.RED"])
+}
@@ -0,0 +1,15 @@
+package client
+
+import "context"
+
+// Chain returns the details of the first extractor that finds a client name
+func Chain(extractors ...Extractor) Extractor {
+	return func(ctx context.Context) Details {
+		for _, extractor := range extractors {
+			if details := extractor(ctx); details.Name != "" {
+				return details
+			}
+		}
+		return Details{}
+	}
+}
@@ -3,8 +3,20 @@ package client
 import "context"
 
 type Details struct {
-	Name    string
-	Version string
+	Name       string
+	Version    string
+	Dimensions map[string]string // Extra reporting dimensions, the number of values per dimension is capped
 }
 
 type Extractor func(context.Context) Details
+
+type DimensionsExtractor func(context.Context) map[string]string
+
+// WithDimensions adds the dimensions to the details found by the extractor
+func WithDimensions(extractor Extractor, dimensions DimensionsExtractor) Extractor {
+	return func(ctx context.Context) Details {
+		details := extractor(ctx)
+		details.Dimensions = dimensions(ctx)
+		return details
+	}
+}
@@ -0,0 +1,38 @@
+package client
+
+import "context"
+
+// HeaderExtractor reads the client details from the given headers of the request stored by RequestMiddleware
+func HeaderExtractor(nameHeader string, versionHeader string) Extractor {
+	return func(ctx context.Context) Details {
+		header := GetRequest(ctx).Header
+		return Details{
+			Name:    header.Get(nameHeader),
+			Version: header.Get(versionHeader),
+		}
+	}
+}
+
+// QueryExtractor reads the client details from the given query string parameters
+func QueryExtractor(nameParam string, versionParam string) Extractor {
+	return func(ctx context.Context) Details {
+		query := GetRequest(ctx).Query
+		return Details{
+			Name:    query.Get(nameParam),
+			Version: query.Get(versionParam),
+		}
+	}
+}
+
+// ExtensionsExtractor reads the client details from the clientLibrary of the request extensions
+// as sent by the Apollo clients: {"extensions": {"clientLibrary": {"name": "...", "version": "..."}}}.
+// The extensions of a POST request are only known once parsed by the server, like in the gqlgen extension.
+func ExtensionsExtractor(ctx context.Context) Details {
+	library, _ := GetRequest(ctx).Extensions["clientLibrary"].(map[string]interface{})
+	name, _ := library["name"].(string)
+	version, _ := library["version"].(string)
+	return Details{
+		Name:    name,
+		Version: version,
+	}
+}
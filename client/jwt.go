@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// TokenVerifier verifies a bearer token and returns its claims
+type TokenVerifier func(ctx context.Context, token string) (map[string]interface{}, error)
+
+var defaultNameClaims = []string{"azp", "client_id"}
+
+// JWTExtractor reads the client details from the claims of the bearer token of the Authorization header.
+// The name is read from the first present claim of nameClaims (default to azp and client_id) and the version
+// from versionClaim if not empty. Without verifier, the claims are decoded without verifying the token,
+// which is acceptable for metrics but the details should not be trusted for anything else.
+func JWTExtractor(nameClaims []string, versionClaim string, verifier TokenVerifier) Extractor {
+	if len(nameClaims) == 0 {
+		nameClaims = defaultNameClaims
+	}
+	if verifier == nil {
+		verifier = unverifiedClaims
+	}
+	return func(ctx context.Context) Details {
+		authorization := GetRequest(ctx).Header.Get("Authorization")
+		if len(authorization) < 7 || !strings.EqualFold(authorization[:7], "bearer ") {
+			return Details{}
+		}
+		claims, err := verifier(ctx, strings.TrimSpace(authorization[7:]))
+		if err != nil {
+			return Details{}
+		}
+
+		details := Details{}
+		for _, claim := range nameClaims {
+			if name, ok := claims[claim].(string); ok && name != "" {
+				details.Name = name
+				break
+			}
+		}
+		if versionClaim != "" {
+			details.Version, _ = claims[versionClaim].(string)
+		}
+		return details
+	}
+}
+
+func unverifiedClaims(_ context.Context, token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, err
+	}
+	claims := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// {"alg":"none"}.{"azp":"ios-app","app_version":"5.2"}
+const testToken = "eyJhbGciOiJub25lIn0.eyJhenAiOiJpb3MtYXBwIiwiYXBwX3ZlcnNpb24iOiI1LjIifQ.signature"
+
+func TestJWT_UnverifiedClaims(t *testing.T) {
+	ctx := givenAuthorization("Bearer " + testToken)
+
+	details := JWTExtractor(nil, "app_version", nil)(ctx)
+	assert.Equal(t, Details{Name: "ios-app", Version: "5.2"}, details)
+}
+
+func TestJWT_Verifier(t *testing.T) {
+	ctx := givenAuthorization("Bearer " + testToken)
+
+	details := JWTExtractor([]string{"client_id"}, "", func(context.Context, string) (map[string]interface{}, error) {
+		return map[string]interface{}{"client_id": "verified"}, nil
+	})(ctx)
+	assert.Equal(t, Details{Name: "verified"}, details)
+
+	details = JWTExtractor(nil, "", func(context.Context, string) (map[string]interface{}, error) {
+		return nil, errors.New("invalid signature")
+	})(ctx)
+	assert.Equal(t, Details{}, details)
+}
+
+func TestJWT_Chain(t *testing.T) {
+	ctx := givenAuthorization("Basic dXNlcjpwYXNz")
+
+	extractor := Chain(JWTExtractor(nil, "", nil), func(context.Context) Details {
+		return Details{Name: "fallback"}
+	})
+	assert.Equal(t, Details{Name: "fallback"}, extractor(ctx))
+}
+
+func givenAuthorization(authorization string) context.Context {
+	header := http.Header{}
+	header.Set("Authorization", authorization)
+	return WithRequest(context.Background(), &Request{Header: header})
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Other replaces the client names and versions that are not allowed
+const Other = "__other__"
+
+// Normalizer is applied to the extracted details to bound the reported clients
+type Normalizer func(Details) Details
+
+var semverRegex = regexp.MustCompile(`^(v?\d+\.\d+)\.\d+([-+].*)?$`)
+
+// CollapsePatchVersion reports the semver versions without patch, pre-release and build (1.2.3-beta becomes 1.2)
+func CollapsePatchVersion(details Details) Details {
+	if matches := semverRegex.FindStringSubmatch(details.Version); matches != nil {
+		details.Version = matches[1]
+	}
+	return details
+}
+
+// AllowNames reports the clients whose names are not allowed (case insensitive) as __other__
+func AllowNames(names ...string) Normalizer {
+	allowed := make(map[string]bool, len(names))
+	for _, name := range names {
+		allowed[strings.ToLower(name)] = true
+	}
+	return func(details Details) Details {
+		if !allowed[strings.ToLower(details.Name)] {
+			details.Name = Other
+			details.Version = Other
+		}
+		return details
+	}
+}
+
+// ChainNormalizers applies the normalizers in order
+func ChainNormalizers(normalizers ...Normalizer) Normalizer {
+	return func(details Details) Details {
+		for _, normalizer := range normalizers {
+			details = normalizer(details)
+		}
+		return details
+	}
+}
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizer_CollapsePatchVersion(t *testing.T) {
+	versions := map[string]string{
+		"1.2.3":              "1.2",
+		"v1.2.3":             "v1.2",
+		"1.2.3-beta.1":       "1.2",
+		"1.2.3+build.5":      "1.2",
+		"1.2":                "1.2",
+		"2021.01.05-nightly": "2021.01",
+		"abc":                "abc",
+	}
+	for version, expected := range versions {
+		assert.Equal(t, expected, CollapsePatchVersion(Details{Version: version}).Version, version)
+	}
+}
+
+func TestNormalizer_AllowNames(t *testing.T) {
+	normalizer := AllowNames("iOS", "web")
+
+	assert.Equal(t, Details{Name: "ios", Version: "1"}, normalizer(Details{Name: "ios", Version: "1"}))
+	assert.Equal(t, Details{Name: Other, Version: Other}, normalizer(Details{Name: "curl", Version: "7"}))
+}
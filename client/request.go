@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type requestKey struct{}
+
+// Request holds the parts of the GraphQL request used by the extractors
+type Request struct {
+	Header     http.Header
+	Query      url.Values
+	Extensions map[string]interface{}
+}
+
+func WithRequest(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, request)
+}
+
+func GetRequest(ctx context.Context) *Request {
+	if request, ok := ctx.Value(requestKey{}).(*Request); ok {
+		return request
+	}
+	return &Request{}
+}
+
+// RequestMiddleware stores the headers and query of the http request in the context for the extractors.
+// The extensions are read from the query of the GET requests, the body of the POST requests is not read
+// since it belongs to the GraphQL server: the gqlgen extension completes them once the request is parsed.
+func RequestMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		ctx := WithRequest(r.Context(), &Request{
+			Header:     r.Header,
+			Query:      query,
+			Extensions: queryExtensions(query),
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// queryExtensions decodes the extensions parameter of a GET request, they are ignored if invalid
+func queryExtensions(query url.Values) map[string]interface{} {
+	raw := query.Get("extensions")
+	if raw == "" {
+		return nil
+	}
+	var extensions map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &extensions); err != nil {
+		return nil
+	}
+	return extensions
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest_QueryExtensions(t *testing.T) {
+	var details Details
+	handler := RequestMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		details = ExtensionsExtractor(r.Context())
+	}))
+	target := "/graphql?" + url.Values{
+		"query":      {"{ me { id } }"},
+		"extensions": {`{"clientLibrary": {"name": "apollo-ios", "version": "0.40.0"}}`},
+	}.Encode()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	assert.Equal(t, Details{Name: "apollo-ios", Version: "0.40.0"}, details)
+}
+
+func TestRequest_InvalidExtensions(t *testing.T) {
+	var request *Request
+	handler := RequestMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = GetRequest(r.Context())
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/graphql?extensions=%7B", nil))
+	assert.Nil(t, request.Extensions)
+	assert.Equal(t, Details{}, ExtensionsExtractor(WithRequest(context.Background(), request)))
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"strings"
+)
+
+// userAgentFamilies is ordered from the most specific product to the most generic,
+// browsers based on Chrome also advertise Chrome and Safari for example
+var userAgentFamilies = []struct {
+	product string
+	family  string
+}{
+	// Mobile SDKs and http clients
+	{"apollo-ios", "Apollo iOS"},
+	{"apollo-android", "Apollo Android"},
+	{"okhttp", "OkHttp"},
+	{"alamofire", "Alamofire"},
+	{"dart", "Dart"},
+	{"cfnetwork", "CFNetwork"},
+	{"dalvik", "Android"},
+	// Browsers
+	{"edg", "Edge"},
+	{"edge", "Edge"},
+	{"opr", "Opera"},
+	{"samsungbrowser", "Samsung Internet"},
+	{"fxios", "Firefox"},
+	{"firefox", "Firefox"},
+	{"crios", "Chrome"},
+	{"chrome", "Chrome"},
+	{"safari", "Safari"},
+}
+
+// UserAgentExtractor detects the browser or mobile SDK family and version from the User-Agent header
+func UserAgentExtractor(ctx context.Context) Details {
+	return ParseUserAgent(GetRequest(ctx).Header.Get("User-Agent"))
+}
+
+func ParseUserAgent(userAgent string) Details {
+	products := userAgentProducts(userAgent)
+	for _, f := range userAgentFamilies {
+		if version, ok := products[f.product]; ok {
+			// Safari advertises the WebKit build as product version
+			if f.product == "safari" && products["version"] != "" {
+				version = products["version"]
+			}
+			return Details{Name: f.family, Version: version}
+		}
+	}
+	return Details{}
+}
+
+// userAgentProducts returns the products of the User-Agent ("name/version") indexed by lowercase name,
+// the comments between parentheses are skipped
+func userAgentProducts(userAgent string) map[string]string {
+	products := make(map[string]string)
+	depth := 0
+	for _, token := range strings.FieldsFunc(userAgent, func(r rune) bool { return r == ' ' || r == '\t' }) {
+		if depth > 0 || strings.HasPrefix(token, "(") {
+			depth += strings.Count(token, "(") - strings.Count(token, ")")
+			continue
+		}
+		parts := strings.SplitN(token, "/", 2)
+		name := strings.ToLower(parts[0])
+		if _, ok := products[name]; ok || name == "" {
+			continue
+		}
+		if len(parts) == 2 {
+			products[name] = parts[1]
+		} else {
+			products[name] = ""
+		}
+	}
+	return products
+}
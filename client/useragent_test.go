@@ -0,0 +1,37 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAgent_Browsers(t *testing.T) {
+	userAgents := map[string]Details{
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/87.0.4280.88 Safari/537.36":                            {Name: "Chrome", Version: "87.0.4280.88"},
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/87.0.4280.88 Safari/537.36 Edg/87.0.664.66":            {Name: "Edge", Version: "87.0.664.66"},
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:84.0) Gecko/20100101 Firefox/84.0":                                                            {Name: "Firefox", Version: "84.0"},
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 14_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/14.0.1 Mobile/15E148 Safari/604.1":     {Name: "Safari", Version: "14.0.1"},
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 14_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/87.0.4280.77 Mobile/15E148 Safari/604.1": {Name: "Chrome", Version: "87.0.4280.77"},
+	}
+	for userAgent, expected := range userAgents {
+		assert.Equal(t, expected, ParseUserAgent(userAgent), userAgent)
+	}
+}
+
+func TestUserAgent_MobileSDKs(t *testing.T) {
+	userAgents := map[string]Details{
+		"okhttp/4.9.0":                           {Name: "OkHttp", Version: "4.9.0"},
+		"MyApp/5.2 CFNetwork/1206 Darwin/20.1.0": {Name: "CFNetwork", Version: "1206"},
+		"Dart/2.10 (dart:io)":                    {Name: "Dart", Version: "2.10"},
+		"Dalvik/2.1.0 (Linux; U; Android 11; Pixel 5 Build/RQ1A.201205.003)": {Name: "Android", Version: "2.1.0"},
+	}
+	for userAgent, expected := range userAgents {
+		assert.Equal(t, expected, ParseUserAgent(userAgent), userAgent)
+	}
+}
+
+func TestUserAgent_Unknown(t *testing.T) {
+	assert.Equal(t, Details{}, ParseUserAgent(""))
+	assert.Equal(t, Details{}, ParseUserAgent("curl/7.64.1"))
+}
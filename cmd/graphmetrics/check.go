@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/graphmetrics/graphmetrics-go/checker"
+)
+
+// stringsFlag is a flag that can be repeated
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runCheck exits with 1 if a change is breaking, so it can gate a CI
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	oldPath := flags.String("old", "", "SDL of the current schema")
+	newPath := flags.String("new", "", "SDL of the new schema")
+	jsonOutput := flags.Bool("json", false, "print the changes as JSON")
+	all := flags.Bool("all", false, "print the safe changes too")
+	var usagePaths stringsFlag
+	flags.Var(&usagePaths, "usage", "usage exported by the SDK (JSON stream), required and can be repeated to cover a window")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *oldPath == "" || *newPath == "" || len(usagePaths) == 0 {
+		return fail(errors.New("the -old and -new schemas and at least one -usage are required"))
+	}
+
+	oldSDL, err := ioutil.ReadFile(*oldPath)
+	if err != nil {
+		return fail(err)
+	}
+	newSDL, err := ioutil.ReadFile(*newPath)
+	if err != nil {
+		return fail(err)
+	}
+	usage := checker.NewUsage()
+	for _, path := range usagePaths {
+		u, err := loadUsage(path)
+		if err != nil {
+			return fail(err)
+		}
+		usage.Merge(u)
+	}
+	// Without usage every change would be safe
+	if len(usage.Clients) == 0 {
+		return fail(errors.New("the usage has no client, the changes cannot be checked"))
+	}
+
+	changes, err := checker.Check(string(oldSDL), string(newSDL), usage)
+	if err != nil {
+		return fail(err)
+	}
+	if !*all {
+		changes = unsafeChanges(changes)
+	}
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(changes); err != nil {
+			return fail(err)
+		}
+	} else {
+		for _, c := range changes {
+			fmt.Println(c)
+		}
+	}
+	if checker.HasBreaking(changes) {
+		return 1
+	}
+	return 0
+}
+
+func loadUsage(path string) (*checker.Usage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	usage, err := checker.LoadUsage(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load usage %s: %w", path, err)
+	}
+	return usage, nil
+}
+
+func unsafeChanges(changes []checker.Change) []checker.Change {
+	unsafe := changes[:0]
+	for _, c := range changes {
+		if c.Severity != checker.Safe {
+			unsafe = append(unsafe, c)
+		}
+	}
+	return unsafe
+}
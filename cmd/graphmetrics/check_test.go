@@ -0,0 +1,27 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck_UsageRequired(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "check")
+	defer os.RemoveAll(dir)
+	oldPath := filepath.Join(dir, "old.graphql")
+	newPath := filepath.Join(dir, "new.graphql")
+	emptyPath := filepath.Join(dir, "empty.json")
+	usagePath := filepath.Join(dir, "usage.json")
+	_ = ioutil.WriteFile(oldPath, []byte("type Query { a: String b: String }"), 0644)
+	_ = ioutil.WriteFile(newPath, []byte("type Query { a: String }"), 0644)
+	_ = ioutil.WriteFile(emptyPath, []byte(`{"clients": {}}`), 0644)
+	_ = ioutil.WriteFile(usagePath, []byte(`{"clients": {"ios 5.2": {"fields": {"Query.b": 3}}}}`), 0644)
+
+	assert.Equal(t, 2, runCheck([]string{"-old", oldPath, "-new", newPath}))
+	assert.Equal(t, 2, runCheck([]string{"-old", oldPath, "-new", newPath, "-usage", emptyPath}))
+	assert.Equal(t, 1, runCheck([]string{"-old", oldPath, "-new", newPath, "-usage", usagePath}))
+}
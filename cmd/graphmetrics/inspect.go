@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+	"github.com/graphmetrics/graphmetrics-go/internal/models/reportingpb"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// report is the part of the UsageMetrics payload that is inspected, the sketches are decoded from the histograms
+type report struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Metrics   []contextReport `json:"metrics"`
+}
+
+type contextReport struct {
+	Context    models.MetricsContext    `json:"context"`
+	Operations map[string]metricsReport `json:"operations"`
+	Types      map[string]typeReport    `json:"types"`
+}
+
+type typeReport struct {
+	Fields map[string]metricsReport `json:"fields"`
+}
+
+type metricsReport struct {
+	Count      int32            `json:"count"`
+	ErrorCount int32            `json:"errorCount"`
+	Histogram  models.Histogram `json:"Histogram"`
+}
+
+type contextSummary struct {
+	Context    models.MetricsContext `json:"context"`
+	Operations []metricsSummary      `json:"operations"`
+	Fields     []metricsSummary      `json:"fields"`
+}
+
+type metricsSummary struct {
+	Name       string   `json:"name"` // Hash (or name) of the operation, Type.field of the field
+	Count      int32    `json:"count"`
+	ErrorCount int32    `json:"errorCount"`
+	ErrorRate  float64  `json:"errorRate"`
+	Latency    *latency `json:"latency,omitempty"`
+}
+
+type latency struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+}
+
+// runInspect prints the metrics of captured payloads or dumps, the payloads are JSON or protobuf, gzip, zstd or uncompressed
+func runInspect(args []string) int {
+	flags := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	definitionsPath := flags.String("definitions", "", "definitions payload used to name the operations")
+	jsonOutput := flags.Bool("json", false, "print the metrics as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: graphmetrics inspect [flags] <payload>... (- reads the standard input)")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		return fail(errors.New("at least one payload is required"))
+	}
+
+	names := map[string]string{}
+	if *definitionsPath != "" {
+		definitions, err := decodeDefinitions(*definitionsPath)
+		if err != nil {
+			return fail(err)
+		}
+		addNames(names, definitions)
+	}
+
+	// The definitions of a dump name the operations of all the payloads
+	var reports []report
+	for _, path := range flags.Args() {
+		p, err := decodeReports(path)
+		if err != nil {
+			return fail(err)
+		}
+		reports = append(reports, p.reports...)
+		addNames(names, p.definitions)
+	}
+	var summaries []contextSummary
+	for _, r := range reports {
+		s, err := summarize(r, names)
+		if err != nil {
+			return fail(err)
+		}
+		summaries = append(summaries, s...)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(summaries); err != nil {
+			return fail(err)
+		}
+		return 0
+	}
+	for _, s := range summaries {
+		printSummary(s)
+	}
+	return 0
+}
+
+func addNames(names map[string]string, definitions []models.UsageDefinitions) {
+	for _, definition := range definitions {
+		for _, d := range definition.Operations {
+			if d.Name != "" {
+				names[d.Hash] = d.Name
+			}
+		}
+	}
+}
+
+// payload holds the documents of a file, a captured report or a dump of many reports and definitions
+type payload struct {
+	reports     []report
+	definitions []models.UsageDefinitions
+}
+
+// decodeDefinitions decodes a definitions payload, the reports of a JSON stream are ignored
+func decodeDefinitions(path string) ([]models.UsageDefinitions, error) {
+	data, err := readPayload(path)
+	if err != nil {
+		return nil, err
+	}
+	if isJSON(data) {
+		p, err := decodeJSONStream(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %w", path, err)
+		}
+		return p.definitions, nil
+	}
+	decoded := &reportingpb.UsageDefinitions{}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %w", path, err)
+	}
+	var definitions models.UsageDefinitions
+	for _, d := range decoded.Operations {
+		definitions.Operations = append(definitions.Operations, models.OperationDefinition{Name: d.Name, Hash: d.Hash})
+	}
+	return []models.UsageDefinitions{definitions}, nil
+}
+
+// decodeReports decodes a metrics payload, JSON payloads can be a stream of reports and definitions
+func decodeReports(path string) (payload, error) {
+	data, err := readPayload(path)
+	if err != nil {
+		return payload{}, err
+	}
+	if isJSON(data) {
+		p, err := decodeJSONStream(data)
+		if err != nil {
+			return p, fmt.Errorf("unable to decode %s: %w", path, err)
+		}
+		return p, nil
+	}
+	decoded := &reportingpb.UsageMetrics{}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		return payload{}, fmt.Errorf("unable to decode %s: %w", path, err)
+	}
+	return payload{reports: []report{reportFromProto(decoded)}}, nil
+}
+
+// decodeJSONStream decodes concatenated documents (indented or one per line), like the dumps of the SDK.
+// The definitions are told apart from the metrics by their hash version.
+func decodeJSONStream(data []byte) (payload, error) {
+	var p payload
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var document json.RawMessage
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return p, nil
+		}
+		if err != nil {
+			return p, err
+		}
+		var kind struct {
+			HashVersion *int `json:"hashVersion"`
+		}
+		if err := json.Unmarshal(document, &kind); err != nil {
+			return p, err
+		}
+		if kind.HashVersion != nil {
+			var definitions models.UsageDefinitions
+			if err := json.Unmarshal(document, &definitions); err != nil {
+				return p, err
+			}
+			p.definitions = append(p.definitions, definitions)
+			continue
+		}
+		var r report
+		if err := json.Unmarshal(document, &r); err != nil {
+			return p, err
+		}
+		p.reports = append(p.reports, r)
+	}
+}
+
+// isJSON detects the JSON payloads, no protobuf payload starts with a brace (field 15 with the group wire type)
+func isJSON(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("{"))
+}
+
+// readPayload reads the decompressed payload
+func readPayload(path string) ([]byte, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		r = br
+	}
+	return ioutil.ReadAll(r)
+}
+
+func reportFromProto(m *reportingpb.UsageMetrics) report {
+	r := report{Timestamp: m.Timestamp.AsTime()}
+	for _, c := range m.Metrics {
+		context := contextReport{
+			Operations: map[string]metricsReport{},
+			Types:      map[string]typeReport{},
+		}
+		if c.Context != nil {
+			context.Context = models.MetricsContext{
+				ClientName:    c.Context.ClientName,
+				ClientVersion: c.Context.ClientVersion,
+				ServerVersion: c.Context.ServerVersion,
+				Dimensions:    c.Context.Dimensions,
+			}
+		}
+		for hash, o := range c.Operations {
+			context.Operations[hash] = metricsReport{Count: o.Count, ErrorCount: o.ErrorCount, Histogram: histogramFromProto(o.Histogram)}
+		}
+		for typeName, t := range c.Types {
+			fields := map[string]metricsReport{}
+			for fieldName, f := range t.Fields {
+				fields[fieldName] = metricsReport{Count: f.Count, ErrorCount: f.ErrorCount, Histogram: histogramFromProto(f.Histogram)}
+			}
+			context.Types[typeName] = typeReport{Fields: fields}
+		}
+		r.Metrics = append(r.Metrics, context)
+	}
+	return r
+}
+
+func histogramFromProto(h *reportingpb.Histogram) models.Histogram {
+	if h == nil {
+		return models.Histogram{}
+	}
+	histogram := models.Histogram{
+		Indexes:   h.Indexes,
+		Counts:    h.Counts,
+		ZeroCount: h.ZeroCount,
+		Min:       h.Min,
+		Max:       h.Max,
+		Sum:       h.Sum,
+	}
+	if h.Mapping != nil {
+		histogram.Mapping = models.SketchMapping{
+			Gamma:         h.Mapping.Gamma,
+			IndexOffset:   h.Mapping.IndexOffset,
+			Interpolation: strings.ToLower(h.Mapping.Interpolation.String()),
+		}
+	}
+	return histogram
+}
+
+func summarize(r report, names map[string]string) ([]contextSummary, error) {
+	summaries := make([]contextSummary, 0, len(r.Metrics))
+	for _, m := range r.Metrics {
+		s := contextSummary{Context: m.Context}
+		for hash, o := range m.Operations {
+			name := hash
+			if n, ok := names[hash]; ok {
+				name = n
+			}
+			summary, err := newMetricsSummary(name, o.Count, o.ErrorCount, o.Histogram)
+			if err != nil {
+				return nil, err
+			}
+			s.Operations = append(s.Operations, summary)
+		}
+		for typeName, t := range m.Types {
+			for fieldName, f := range t.Fields {
+				summary, err := newMetricsSummary(typeName+"."+fieldName, f.Count, f.ErrorCount, f.Histogram)
+				if err != nil {
+					return nil, err
+				}
+				s.Fields = append(s.Fields, summary)
+			}
+		}
+		sortSummaries(s.Operations)
+		sortSummaries(s.Fields)
+		summaries = append(summaries, s)
+	}
+	return summaries, nil
+}
+
+func newMetricsSummary(name string, count int32, errorCount int32, histogram models.Histogram) (metricsSummary, error) {
+	s := metricsSummary{Name: name, Count: count, ErrorCount: errorCount}
+	if count > 0 {
+		s.ErrorRate = float64(errorCount) / float64(count)
+	}
+	sketch, err := histogram.Sketch()
+	if err != nil {
+		return s, fmt.Errorf("invalid histogram of %s: %w", name, err)
+	}
+	if sketch.Count() == 0 {
+		return s, nil
+	}
+	s.Latency = &latency{}
+	for q, d := range map[float64]*time.Duration{0.5: &s.Latency.P50, 0.95: &s.Latency.P95, 0.99: &s.Latency.P99} {
+		v, err := sketch.Quantile(q)
+		if err != nil {
+			return s, err
+		}
+		*d = time.Duration(v)
+	}
+	return s, nil
+}
+
+// sortSummaries sorts the most requested first
+func sortSummaries(summaries []metricsSummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+}
+
+func printSummary(s contextSummary) {
+	fmt.Printf("client %s %s, server %s", s.Context.ClientName, s.Context.ClientVersion, s.Context.ServerVersion)
+	dimensions := make([]string, 0, len(s.Context.Dimensions))
+	for k, v := range s.Context.Dimensions {
+		dimensions = append(dimensions, k+"="+v)
+	}
+	sort.Strings(dimensions)
+	if len(dimensions) > 0 {
+		fmt.Printf(", %s", strings.Join(dimensions, " "))
+	}
+	fmt.Print("\n\n")
+	printTable("OPERATION", s.Operations)
+	printTable("FIELD", s.Fields)
+}
+
+func printTable(title string, summaries []metricsSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tCOUNT\tERRORS\tERROR %%\tP50\tP95\tP99\n", title)
+	for _, s := range summaries {
+		p50, p95, p99 := "-", "-", "-"
+		if s.Latency != nil {
+			p50 = roundDuration(s.Latency.P50).String()
+			p95 = roundDuration(s.Latency.P95).String()
+			p99 = roundDuration(s.Latency.P99).String()
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f\t%s\t%s\t%s\n", s.Name, s.Count, s.ErrorCount, 100*s.ErrorRate, p50, p95, p99)
+	}
+	_ = w.Flush()
+	fmt.Println()
+}
+
+// roundDuration keeps two decimals of the largest unit
+func roundDuration(d time.Duration) time.Duration {
+	for _, unit := range []time.Duration{time.Second, time.Millisecond, time.Microsecond} {
+		if d >= unit {
+			return d.Round(unit / 100)
+		}
+	}
+	return d
+}
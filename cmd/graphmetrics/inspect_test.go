@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphmetrics/graphmetrics-go"
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+)
+
+func TestInspect_Summarize(t *testing.T) {
+	metrics := givenUsageMetrics()
+	data, _ := json.Marshal(metrics)
+	var r report
+	assert.NoError(t, json.Unmarshal(data, &r))
+
+	summaries, err := summarize(r, map[string]string{"hash": "GetUser"})
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "web", summaries[0].Context.ClientName)
+
+	operations := summaries[0].Operations
+	assert.Len(t, operations, 2)
+	assert.Equal(t, "GetUser", operations[0].Name)
+	assert.Equal(t, int32(4), operations[0].Count)
+	assert.Equal(t, 0.25, operations[0].ErrorRate)
+	assert.InDelta(t, float64(3*time.Millisecond), float64(operations[0].Latency.P50), float64(time.Millisecond)/10)
+	assert.Equal(t, "other", operations[1].Name)
+	assert.Nil(t, operations[1].Latency)
+
+	fields := summaries[0].Fields
+	assert.Len(t, fields, 1)
+	assert.Equal(t, "User.name", fields[0].Name)
+	assert.Equal(t, int32(1), fields[0].Count)
+}
+
+func TestInspect_DecodeProtobuf(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "payloads")
+	defer os.RemoveAll(dir)
+	metrics := givenUsageMetrics()
+	data, _ := json.Marshal(metrics)
+	jsonPath := filepath.Join(dir, "metrics.json")
+	protoPath := filepath.Join(dir, "metrics.pb.gz")
+	_ = ioutil.WriteFile(jsonPath, data, 0644)
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	data, _ = metrics.MarshalProto()
+	_, _ = w.Write(data)
+	_ = w.Close()
+	_ = ioutil.WriteFile(protoPath, buf.Bytes(), 0644)
+
+	fromJSON, err := decodeReports(jsonPath)
+	assert.NoError(t, err)
+	fromProto, err := decodeReports(protoPath)
+	assert.NoError(t, err)
+	assert.Len(t, fromJSON.reports, 1)
+	assert.Len(t, fromProto.reports, 1)
+	assert.True(t, fromJSON.reports[0].Timestamp.Equal(fromProto.reports[0].Timestamp))
+
+	expected, err := summarize(fromJSON.reports[0], nil)
+	assert.NoError(t, err)
+	actual, err := summarize(fromProto.reports[0], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestInspect_DumpFile(t *testing.T) {
+	for _, format := range []graphmetrics.DumpFormat{graphmetrics.JSONDump, graphmetrics.NDJSONDump} {
+		dir, _ := ioutil.TempDir("", "dump")
+		path := filepath.Join(dir, "dump.json")
+		// Every run appends its metrics and definitions to the dump
+		for i := 0; i < 2; i++ {
+			aggregator := graphmetrics.NewAggregator(&graphmetrics.Configuration{
+				Advanced: &graphmetrics.AdvancedConfiguration{DryRun: true, DumpFile: path, DumpFormat: format},
+			})
+			go aggregator.Start()
+			aggregator.PushOperation(&graphmetrics.OperationMessage{Name: "GetUser", Hash: "hash", Duration: time.Millisecond})
+			assert.NoError(t, aggregator.Stop())
+		}
+
+		p, err := decodeReports(path)
+		assert.NoError(t, err, format)
+		assert.Len(t, p.reports, 2)
+		assert.Len(t, p.definitions, 2)
+		names := map[string]string{}
+		addNames(names, p.definitions)
+		summaries, err := summarize(p.reports[1], names)
+		assert.NoError(t, err)
+		assert.Equal(t, "GetUser", summaries[0].Operations[0].Name)
+		assert.Equal(t, int32(1), summaries[0].Operations[0].Count)
+		os.RemoveAll(dir)
+	}
+}
+
+func givenUsageMetrics() *models.UsageMetrics {
+	metrics := models.NewUsageMetrics()
+	context := metrics.FindContextMetrics(models.MetricsContext{ClientName: "web", ClientVersion: "1.0"})
+	operation := context.FindOperationMetrics("hash")
+	for _, d := range []time.Duration{2 * time.Millisecond, 3 * time.Millisecond, 3 * time.Millisecond, 100 * time.Millisecond} {
+		operation.Count++
+		_ = operation.Histogram.Add(float64(d))
+	}
+	operation.ErrorCount = 1
+	context.FindOperationMetrics("other")
+	field := context.FindTypeMetrics("User").FindFieldMetrics("name")
+	field.Count++
+	_ = field.Histogram.Add(float64(time.Millisecond))
+	return metrics
+}
@@ -0,0 +1,51 @@
+// Command graphmetrics provides offline tools built on the SDK packages
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) int // Returns the exit code
+}
+
+var commands = map[string]command{
+	"check":     {usage: "check schema changes against the usage exported by the SDK", run: runCheck},
+	"inspect":   {usage: "print the metrics of captured report payloads", run: runInspect},
+	"signature": {usage: "compute the signatures and hashes of the operations of a client", run: runSignature},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %s\n", os.Args[1])
+		printUsage()
+		os.Exit(2)
+	}
+	os.Exit(cmd.run(os.Args[2:]))
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: graphmetrics <command> [flags]")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+// fail prints the error and returns the exit code of a failure
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	return 2
+}
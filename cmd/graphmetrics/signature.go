@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/graphmetrics/graphmetrics-go/registry"
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+// document is an operation as it would be received at runtime
+type document struct {
+	source string // File or manifest entry of the operation
+	name   string
+	query  string
+}
+
+type signatureResult struct {
+	Source    string `json:"source"`
+	Name      string `json:"name,omitempty"`
+	Hash      string `json:"hash,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// runSignature exits with 1 if the signature of an operation cannot be computed
+func runSignature(args []string) int {
+	flags := flag.NewFlagSet("signature", flag.ContinueOnError)
+	dir := flags.String("dir", "", "directory of .graphql documents, the fragments are shared between the files")
+	manifest := flags.String("manifest", "", "query manifest extracted from a client build")
+	registryPath := flags.String("registry", "", "registry file (used as safelist) where the operations are added")
+	jsonOutput := flags.Bool("json", false, "print the signatures as JSON")
+	var schemaPaths stringsFlag
+	flags.Var(&schemaPaths, "schema", "SDL of the schema, can be repeated if split in several files")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(schemaPaths) == 0 || (*dir == "") == (*manifest == "") {
+		return fail(errors.New("the -schema and either -dir or -manifest are required"))
+	}
+
+	schema, err := loadSchemaFiles(schemaPaths)
+	if err != nil {
+		return fail(err)
+	}
+	var documents []document
+	if *dir != "" {
+		documents, err = loadDirectory(*dir)
+	} else {
+		documents, err = loadManifest(*manifest)
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	code := 0
+	results := make([]signatureResult, 0, len(documents))
+	var operations []registry.Operation
+	for _, d := range documents {
+		result := signatureResult{Source: d.source, Name: d.name}
+		sign, err := signature.OperationSignature(schema, d.query, d.name)
+		var hash string
+		if err == nil {
+			hash, err = signature.OperationHash(sign)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			code = 1
+		} else {
+			result.Hash = hash
+			result.Signature = sign
+			operations = append(operations, registry.Operation{Name: d.name, Hash: hash, Signature: sign})
+		}
+		results = append(results, result)
+	}
+
+	if *registryPath != "" {
+		if err := registerOperations(*registryPath, operations); err != nil {
+			return fail(err)
+		}
+	}
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			return fail(err)
+		}
+	} else {
+		for _, r := range results {
+			printSignature(r)
+		}
+	}
+	return code
+}
+
+func printSignature(r signatureResult) {
+	name := r.Name
+	if name == "" {
+		name = "<anonymous>"
+	}
+	if r.Error != "" {
+		fmt.Printf("%s %s: %s\n\n", r.Source, name, r.Error)
+		return
+	}
+	fmt.Printf("%s %s %s\n%s\n", r.Hash, r.Source, name, r.Signature)
+}
+
+func loadSchemaFiles(paths []string) (*ast.Schema, error) {
+	sources := make([]*ast.Source, 0, len(paths))
+	for _, path := range paths {
+		sdl, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &ast.Source{Name: path, Input: string(sdl)})
+	}
+	schema, err := gqlparser.LoadSchema(sources...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load schema: %w", err)
+	}
+	return schema, nil
+}
+
+// loadDirectory returns every operation of the .graphql files with the fragments of the directory it spreads,
+// like a client build would inline them, so the documents are hashed as they are sent at runtime.
+func loadDirectory(dir string) ([]document, error) {
+	type parsed struct {
+		path     string
+		document *ast.QueryDocument
+	}
+	var files []parsed
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".graphql") {
+			return err
+		}
+		query, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		doc, gqlErr := parser.ParseQuery(&ast.Source{Name: path, Input: string(query)})
+		if gqlErr != nil {
+			return fmt.Errorf("unable to parse %s: %w", path, gqlErr)
+		}
+		files = append(files, parsed{path: path, document: doc})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var fragments ast.FragmentDefinitionList
+	for _, f := range files {
+		fragments = append(fragments, f.document.Fragments...)
+	}
+	var documents []document
+	for _, f := range files {
+		for _, operation := range f.document.Operations {
+			var buf bytes.Buffer
+			formatter.NewFormatter(&buf).FormatQueryDocument(&ast.QueryDocument{
+				Operations: ast.OperationList{operation},
+				Fragments:  spreadFragments(operation, fragments),
+			})
+			documents = append(documents, document{source: f.path, name: operation.Name, query: buf.String()})
+		}
+	}
+	return documents, nil
+}
+
+// spreadFragments returns the fragments transitively spread by the operation, in the order of the directory
+func spreadFragments(operation *ast.OperationDefinition, fragments ast.FragmentDefinitionList) ast.FragmentDefinitionList {
+	spread := map[string]bool{}
+	var walk func(selections ast.SelectionSet)
+	walk = func(selections ast.SelectionSet) {
+		for _, selection := range selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				walk(s.SelectionSet)
+			case *ast.InlineFragment:
+				walk(s.SelectionSet)
+			case *ast.FragmentSpread:
+				if spread[s.Name] {
+					continue
+				}
+				spread[s.Name] = true
+				if fragment := fragments.ForName(s.Name); fragment != nil {
+					walk(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	walk(operation.SelectionSet)
+
+	var used ast.FragmentDefinitionList
+	for _, fragment := range fragments {
+		if spread[fragment.Name] {
+			used = append(used, fragment)
+		}
+	}
+	return used
+}
+
+// loadManifest reads either a persisted query manifest ({"operations": [{"id", "name", "body"}]})
+// or a map of the query ids to their documents
+func loadManifest(path string) ([]document, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest struct {
+		Operations []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			Body string `json:"body"`
+		} `json:"operations"`
+	}
+	if err := json.Unmarshal(data, &manifest); err == nil && manifest.Operations != nil {
+		documents := make([]document, 0, len(manifest.Operations))
+		for _, o := range manifest.Operations {
+			documents = append(documents, document{source: o.ID, name: o.Name, query: o.Body})
+		}
+		return documents, nil
+	}
+
+	var queries map[string]string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("unable to load manifest %s: %w", path, err)
+	}
+	documents := make([]document, 0, len(queries))
+	for id, query := range queries {
+		documents = append(documents, document{source: id, query: query})
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].source < documents[j].source
+	})
+	return documents, nil
+}
+
+// registerOperations adds the operations to the registry file, the known ones are kept
+func registerOperations(path string, operations []registry.Operation) error {
+	existing, err := registry.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	r := registry.NewMemoryRegistry(existing...)
+	for _, o := range operations {
+		if !r.Contains(o.Hash) {
+			_ = r.Register(o)
+		}
+	}
+	return registry.WriteFile(path, r.Operations())
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+const testSchema = `
+type Query {
+	user: User
+	version: String
+}
+
+type User {
+	id: ID!
+	name: String
+}
+`
+
+func TestSignature_LoadDirectory(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "queries")
+	defer os.RemoveAll(dir)
+	user := "query User {\n  user {\n    ...UserFields\n  }\n}\n"
+	fragments := "fragment UserFields on User {\n  id\n  ...UserName\n}\n\nfragment UserName on User {\n  name\n}\n\nfragment Unused on Query {\n  version\n}\n"
+	version := "query Version {\n  version\n}\n"
+	_ = ioutil.WriteFile(filepath.Join(dir, "user.graphql"), []byte(user), 0644)
+	_ = ioutil.WriteFile(filepath.Join(dir, "fragments.graphql"), []byte(fragments), 0644)
+	_ = ioutil.WriteFile(filepath.Join(dir, "version.graphql"), []byte(version), 0644)
+
+	documents, err := loadDirectory(dir)
+	assert.NoError(t, err)
+	assert.Len(t, documents, 2)
+
+	// The client sends each operation with the fragments it spreads
+	schema := givenSchema(t)
+	assertSameHash(t, schema, documents[0], "User", user+"fragment UserFields on User { id ...UserName } fragment UserName on User { name }")
+	assertSameHash(t, schema, documents[1], "Version", version)
+	assert.NotContains(t, documents[1].query, "fragment")
+}
+
+func TestSignature_LoadManifest(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "manifest")
+	defer os.RemoveAll(dir)
+	persisted := filepath.Join(dir, "persisted.json")
+	queries := filepath.Join(dir, "queries.json")
+	_ = ioutil.WriteFile(persisted, []byte(`{"operations": [{"id": "1", "name": "Version", "body": "query Version { version }"}]}`), 0644)
+	_ = ioutil.WriteFile(queries, []byte(`{"2": "{ user { id } }", "1": "query Version { version }"}`), 0644)
+
+	schema := givenSchema(t)
+	documents, err := loadManifest(persisted)
+	assert.NoError(t, err)
+	assert.Equal(t, []document{{source: "1", name: "Version", query: "query Version { version }"}}, documents)
+	assertSameHash(t, schema, documents[0], "Version", "query Version {\n  version\n}")
+
+	documents, err = loadManifest(queries)
+	assert.NoError(t, err)
+	assert.Len(t, documents, 2)
+	assert.Equal(t, "1", documents[0].source)
+	assertSameHash(t, schema, documents[1], "", "{ user { id } }")
+}
+
+func givenSchema(t *testing.T) *ast.Schema {
+	schema, gqlErr := gqlparser.LoadSchema(&ast.Source{Input: testSchema})
+	assert.Nil(t, gqlErr)
+	return schema
+}
+
+// assertSameHash compares the hash of the loaded document to the hash of the document sent by the client
+func assertSameHash(t *testing.T, schema *ast.Schema, d document, name string, sent string) {
+	assert.Equal(t, name, d.name)
+	expected, err := signature.OperationSignature(schema, sent, name)
+	assert.NoError(t, err)
+	actual, err := signature.OperationSignature(schema, d.query, d.name)
+	assert.NoError(t, err)
+	expectedHash, _ := signature.OperationHash(expected)
+	actualHash, _ := signature.OperationHash(actual)
+	assert.Equal(t, expectedHash, actualHash)
+}
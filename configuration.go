@@ -1,12 +1,15 @@
 package graphmetrics
 
 import (
-	"context"
+	"compress/gzip"
+	"io"
 	"time"
 
 	"github.com/graphmetrics/logger-go"
 
 	"github.com/graphmetrics/graphmetrics-go/client"
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+	"github.com/graphmetrics/graphmetrics-go/registry"
 )
 
 const (
@@ -14,14 +17,44 @@ const (
 	defaultFieldBufferSize     = 1000
 	defaultOperationBufferSize = 20
 	defaultStopTimeout         = 10 * time.Second
+	defaultMaxDimensions       = 10
+	defaultMaxDimensionValues  = 50
+	defaultMaxTenants          = 100
+	defaultMaxContexts         = 100
+	defaultMaxOperations       = 1000
+	defaultMaxFields           = 5000
+	defaultMaxPayloadSize      = 8 << 20
+)
+
+type Compression string
+
+const (
+	GzipCompression Compression = "gzip"
+	ZstdCompression Compression = "zstd"
+	NoCompression   Compression = "none" // Only for local debugging
+)
+
+// SketchStore is the store of the latency sketches bins
+type SketchStore string
+
+const (
+	UnboundedSketch         SketchStore = models.UnboundedStore
+	CollapsingLowestSketch  SketchStore = models.CollapsingLowestStore  // Loses the accuracy of the lowest quantiles
+	CollapsingHighestSketch SketchStore = models.CollapsingHighestStore // Loses the accuracy of the highest quantiles
 )
 
 type Configuration struct {
-	ApiKey          string
-	ServerVersion   string
-	ClientExtractor client.Extractor
-	Logger          logger.Logger
-	Advanced        *AdvancedConfiguration
+	ApiKey           string
+	ServerVersion    string
+	ClientExtractor  client.Extractor
+	ClientNormalizer client.Normalizer
+	TenantExtractor  TenantExtractor
+	TenantExporter   TenantExporter
+	UsageExporter    UsageExporter
+	Safelist         registry.Registry // Known operations, the unknown ones are handled according to the SafelistMode
+	SafelistMode     registry.Mode
+	Logger           logger.Logger
+	Advanced         *AdvancedConfiguration
 }
 
 type AdvancedConfiguration struct {
@@ -29,8 +62,25 @@ type AdvancedConfiguration struct {
 	OperationBufferSize int // If operation metrics are dropped consider increasing it
 	Endpoint            string
 	Http                bool
+	Protobuf            bool // Send the reports in protobuf, falls back to json if the endpoint does not support it
+	Compression         Compression
+	GzipLevel           int // From 1 (best speed) to 9 (best compression), default compression otherwise
+	MaxPayloadSize      int // Uncompressed size in bytes over which the metrics are split in multiple reports
 	Debug               bool
 	StopTimeout         time.Duration
+	MaxDimensions       int         // Distinct client dimensions in an interval, the others are reported as __other__
+	MaxDimensionValues  int         // Distinct values per client dimension in an interval, the others are reported as __other__
+	MaxTenants          int         // Heaviest tenants tracked in an interval (__other__ included), the smallest ones are merged in __other__
+	MaxContexts         int         // Distinct clients (with dimensions) in an interval, the others are reported as __other__
+	MaxOperations       int         // Distinct operations in an interval, the others are reported as __other__
+	MaxFields           int         // Distinct fields in an interval, the others are reported as __other__
+	SketchAccuracy      float64     // Relative accuracy of the latency quantiles, between 0.0005 (to index an hour) and 1
+	SketchStore         SketchStore // Collapsing stores bound the memory of each sketch to SketchMaxBins
+	SketchMaxBins       int         // Bins per sketch with a collapsing store (default 2048)
+	DumpWriter          io.Writer   // Every flushed report is written as JSON, e.g. to os.Stdout
+	DumpFile            string      // Every flushed report is appended to the file, created if missing
+	DumpFormat          DumpFormat  // Indented JSON (default) or NDJSON
+	DryRun              bool        // The reports are only dumped and never sent, no API key is needed
 }
 
 func (c *Configuration) GetEndpoint() string {
@@ -47,6 +97,42 @@ func (c *Configuration) GetProtocol() string {
 	return "https"
 }
 
+func (c *Configuration) GetProtobuf() bool {
+	if c.Advanced != nil {
+		return c.Advanced.Protobuf
+	}
+	return false
+}
+
+// GetCompression falls back to gzip if the compression is unknown
+func (c *Configuration) GetCompression() Compression {
+	if c.Advanced == nil || c.Advanced.Compression == "" {
+		return GzipCompression
+	}
+	switch c.Advanced.Compression {
+	case GzipCompression, ZstdCompression, NoCompression:
+		return c.Advanced.Compression
+	}
+	c.GetLogger().Warn("unknown compression, falling back to gzip", map[string]interface{}{
+		"compression": c.Advanced.Compression,
+	})
+	return GzipCompression
+}
+
+func (c *Configuration) GetGzipLevel() int {
+	if c.Advanced != nil && c.Advanced.GzipLevel >= gzip.BestSpeed && c.Advanced.GzipLevel <= gzip.BestCompression {
+		return c.Advanced.GzipLevel
+	}
+	return gzip.DefaultCompression
+}
+
+func (c *Configuration) GetMaxPayloadSize() int {
+	if c.Advanced != nil && c.Advanced.MaxPayloadSize != 0 {
+		return c.Advanced.MaxPayloadSize
+	}
+	return defaultMaxPayloadSize
+}
+
 func (c *Configuration) GetFieldBufferSize() int {
 	if c.Advanced != nil && c.Advanced.FieldBufferSize != 0 {
 		return c.Advanced.FieldBufferSize
@@ -61,6 +147,81 @@ func (c *Configuration) GetOperationBufferSize() int {
 	return defaultOperationBufferSize
 }
 
+func (c *Configuration) GetMaxDimensions() int {
+	if c.Advanced != nil && c.Advanced.MaxDimensions != 0 {
+		return c.Advanced.MaxDimensions
+	}
+	return defaultMaxDimensions
+}
+
+func (c *Configuration) GetMaxDimensionValues() int {
+	if c.Advanced != nil && c.Advanced.MaxDimensionValues != 0 {
+		return c.Advanced.MaxDimensionValues
+	}
+	return defaultMaxDimensionValues
+}
+
+func (c *Configuration) GetMaxTenants() int {
+	if c.Advanced != nil && c.Advanced.MaxTenants != 0 {
+		return c.Advanced.MaxTenants
+	}
+	return defaultMaxTenants
+}
+
+func (c *Configuration) GetMaxContexts() int {
+	if c.Advanced != nil && c.Advanced.MaxContexts != 0 {
+		return c.Advanced.MaxContexts
+	}
+	return defaultMaxContexts
+}
+
+func (c *Configuration) GetMaxOperations() int {
+	if c.Advanced != nil && c.Advanced.MaxOperations != 0 {
+		return c.Advanced.MaxOperations
+	}
+	return defaultMaxOperations
+}
+
+func (c *Configuration) GetMaxFields() int {
+	if c.Advanced != nil && c.Advanced.MaxFields != 0 {
+		return c.Advanced.MaxFields
+	}
+	return defaultMaxFields
+}
+
+// GetSketchConfig falls back to the default sketches if the accuracy or the store is invalid
+func (c *Configuration) GetSketchConfig() models.SketchConfig {
+	if c.Advanced == nil {
+		return models.SketchConfig{}
+	}
+	config := models.SketchConfig{
+		RelativeAccuracy: c.Advanced.SketchAccuracy,
+		Store:            string(c.Advanced.SketchStore),
+		MaxBins:          c.Advanced.SketchMaxBins,
+	}
+	if err := config.Validate(); err != nil {
+		c.GetLogger().Warn("invalid sketch configuration, falling back to the default sketches", map[string]interface{}{
+			"error": err,
+		})
+		return models.SketchConfig{}
+	}
+	return config
+}
+
+func (c *Configuration) GetDumpFormat() DumpFormat {
+	if c.Advanced != nil && c.Advanced.DumpFormat != "" {
+		return c.Advanced.DumpFormat
+	}
+	return JSONDump
+}
+
+func (c *Configuration) GetDryRun() bool {
+	if c.Advanced != nil {
+		return c.Advanced.DryRun
+	}
+	return false
+}
+
 func (c *Configuration) GetDebug() bool {
 	if c.Advanced != nil {
 		return c.Advanced.Debug
@@ -82,11 +243,31 @@ func (c *Configuration) GetLogger() logger.Logger {
 	return logger.NewDefault(c.GetDebug())
 }
 
+// GetSafelistMode falls back to the log mode on an unknown mode, the unknown operations are then only logged
+func (c *Configuration) GetSafelistMode() registry.Mode {
+	switch c.SafelistMode {
+	case "":
+		return registry.LogMode
+	case registry.LogMode, registry.RejectMode, registry.LearnMode:
+		return c.SafelistMode
+	}
+	c.GetLogger().Error("unknown safelist mode, the unknown operations are only logged", map[string]interface{}{
+		"mode": c.SafelistMode,
+	})
+	return registry.LogMode
+}
+
+// GetClientExtractor defaults to the Apollo client details, they work without http middleware using
+// the request extensions, the headers are only available if a middleware stored them (see client.RequestMiddleware)
 func (c *Configuration) GetClientExtractor() client.Extractor {
 	if c.ClientExtractor != nil {
 		return c.ClientExtractor
 	}
-	return func(context.Context) client.Details {
-		return client.Details{}
-	}
+	return defaultClientExtractor
 }
+
+var defaultClientExtractor = client.Chain(
+	client.ApolloExtractor,
+	client.HeaderExtractor("apollographql-client-name", "apollographql-client-version"),
+	client.ExtensionsExtractor,
+)
@@ -0,0 +1,68 @@
+package graphmetrics
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// DumpFormat is the format of the reports written locally
+type DumpFormat string
+
+const (
+	JSONDump   DumpFormat = "json"   // Indented JSON documents
+	NDJSONDump DumpFormat = "ndjson" // One JSON document per line
+)
+
+// dumper writes the reports as JSON, whatever their encoding when sent, so they can be inspected locally
+type dumper struct {
+	lock   sync.Mutex
+	w      io.Writer
+	file   *os.File // Opened by the SDK, closed when the sender stops
+	format DumpFormat
+}
+
+func newDumper(w io.Writer, path string, format DumpFormat) (*dumper, error) {
+	d := &dumper{format: format}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		d.file = f
+	}
+	switch {
+	case w != nil && d.file != nil:
+		d.w = io.MultiWriter(w, d.file)
+	case d.file != nil:
+		d.w = d.file
+	default:
+		d.w = w
+	}
+	return d, nil
+}
+
+func (d *dumper) dump(data interface{}) error {
+	var b []byte
+	var err error
+	if d.format == NDJSONDump {
+		b, err = json.Marshal(data)
+	} else {
+		b, err = json.MarshalIndent(data, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, err = d.w.Write(append(b, '\n'))
+	return err
+}
+
+func (d *dumper) close() error {
+	if d.file == nil {
+		return nil
+	}
+	return d.file.Close()
+}
@@ -3,9 +3,12 @@ module github.com/graphmetrics/graphmetrics-go
 go 1.15
 
 require (
+	github.com/golang/protobuf v1.4.1
 	github.com/graphmetrics/logger-go v0.2.1
 	github.com/graphmetrics/sketches-go v0.2.0
 	github.com/hashicorp/go-retryablehttp v0.6.8
+	github.com/klauspost/compress v1.11.7
 	github.com/stretchr/testify v1.6.1
 	github.com/vektah/gqlparser/v2 v2.1.0
+	google.golang.org/protobuf v1.25.0
 )
@@ -0,0 +1,34 @@
+package graphmetricsgqlgen
+
+import (
+	"reflect"
+)
+
+// enumValues returns the distinct values of an enum (or list of enums) returned by a resolver
+// as schema coordinates, the generated enums are strings
+func enumValues(enum string, res interface{}) []string {
+	seen := map[string]bool{}
+	var values []string
+	var collect func(v reflect.Value)
+	collect = func(v reflect.Value) {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		switch v.Kind() {
+		case reflect.String:
+			if !seen[v.String()] {
+				seen[v.String()] = true
+				values = append(values, enum+"."+v.String())
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				collect(v.Index(i))
+			}
+		}
+	}
+	collect(reflect.ValueOf(res))
+	return values
+}
@@ -0,0 +1,38 @@
+package graphmetricsgqlgen
+
+import (
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+const (
+	complexityLimitError         = "complexity_limit"
+	persistedQueryNotFoundError  = "persisted_query_not_found"
+	complexityLimitErrorCode     = "COMPLEXITY_LIMIT_EXCEEDED"
+	persistedQueryNotFoundCode   = "PERSISTED_QUERY_NOT_FOUND"
+	persistedQueryNotFoundReason = "PersistedQueryNotFound"
+)
+
+// errorClass classifies the errors returned by gqlgen before the execution of an operation
+func errorClass(errs gqlerror.List) string {
+	for _, err := range errs {
+		if err.Message == persistedQueryNotFoundReason {
+			return persistedQueryNotFoundError
+		}
+		code, _ := err.Extensions["code"].(string)
+		switch code {
+		case errcode.ParseFailed:
+			return string(signature.SyntaxError)
+		case errcode.ValidationFailed:
+			return string(signature.ValidationError)
+		case complexityLimitErrorCode:
+			return complexityLimitError
+		case persistedQueryNotFoundCode:
+			return persistedQueryNotFoundError
+		}
+	}
+	// Errors without code are raised by the executor when the operation or its variables are invalid
+	return string(signature.ValidationError)
+}
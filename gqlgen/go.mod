@@ -6,5 +6,6 @@ require (
 	github.com/99designs/gqlgen v0.13.0
 	github.com/graphmetrics/graphmetrics-go v0.3.0
 	github.com/graphmetrics/logger-go v0.2.1
+	github.com/stretchr/testify v1.6.1
 	github.com/vektah/gqlparser/v2 v2.1.0
 )
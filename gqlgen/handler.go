@@ -2,19 +2,29 @@ package graphmetricsgqlgen
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/graphmetrics/logger-go"
 	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 
 	"github.com/graphmetrics/graphmetrics-go"
 	"github.com/graphmetrics/graphmetrics-go/client"
+	"github.com/graphmetrics/graphmetrics-go/registry"
 	"github.com/graphmetrics/graphmetrics-go/signature"
 )
 
+const extensionName = "GraphMetricsExtension"
+
 type Extension interface {
+	graphql.OperationParameterMutator
 	graphql.OperationInterceptor
+	graphql.ResponseInterceptor
 	graphql.FieldInterceptor
 	graphql.HandlerExtension
 
@@ -27,6 +37,9 @@ func NewExtension(cfg *graphmetrics.Configuration) Extension {
 	return &extensionImpl{
 		aggregator:      agg,
 		clientExtractor: cfg.GetClientExtractor(),
+		tenantExtractor: cfg.TenantExtractor,
+		safelist:        cfg.Safelist,
+		safelistMode:    cfg.GetSafelistMode(),
 		logger:          cfg.GetLogger(),
 	}
 }
@@ -34,13 +47,29 @@ func NewExtension(cfg *graphmetrics.Configuration) Extension {
 type extensionImpl struct {
 	aggregator      *graphmetrics.Aggregator
 	clientExtractor client.Extractor
+	tenantExtractor graphmetrics.TenantExtractor
+	safelist        registry.Registry
+	safelistMode    registry.Mode
 	schema          *ast.Schema
 
+	unknownOperations unknownOperations // Logged once per interval in log mode
+
 	logger logger.Logger
 }
 
+// operationStats is stored in the operation context stats to follow the operation lifecycle
+type operationStats struct {
+	resolverTime  int64 // Accessed atomically, first for alignment
+	rawQuery      string
+	operationName string
+	extensions    map[string]interface{}
+	client        client.Details
+	dispatched    bool
+	events        *eventQueue // Only for the subscriptions
+}
+
 func (*extensionImpl) ExtensionName() string {
-	return "GraphMetricsExtension"
+	return extensionName
 }
 
 func (e *extensionImpl) Validate(schema graphql.ExecutableSchema) error {
@@ -48,55 +77,290 @@ func (e *extensionImpl) Validate(schema graphql.ExecutableSchema) error {
 	return nil
 }
 
+func (e *extensionImpl) MutateOperationParameters(ctx context.Context, request *graphql.RawParams) *gqlerror.Error {
+	// Keep the raw parameters since they are not in the operation context if a mutator fails
+	operation := graphql.GetOperationContext(ctx)
+	operation.Stats.SetExtension(extensionName, &operationStats{
+		rawQuery:      request.Query,
+		operationName: request.OperationName,
+		extensions:    request.Extensions,
+	})
+	return nil
+}
+
 func (e *extensionImpl) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
 	operation := graphql.GetOperationContext(ctx)
+	stats := getOperationStats(operation)
+	stats.dispatched = true
+	ctx = withClientRequest(ctx, stats)
 	caller := e.clientExtractor(ctx)
-	sign, err := signature.OperationSignature(e.schema, operation.RawQuery, operation.OperationName)
+	stats.client = caller
+	tenant := ""
+	if e.tenantExtractor != nil {
+		tenant = e.tenantExtractor(ctx)
+	}
+	sign, usage, err := signature.OperationSignatureWithUsage(e.schema, operation.RawQuery, operation.OperationName)
+	var hash string
+	if err == nil {
+		hash, err = signature.OperationHash(sign)
+	}
+	errorKind := ""
 	if err != nil {
-		e.logger.Error("unable to build operation signature", map[string]interface{}{
+		e.logger.Debug("unable to build operation signature", map[string]interface{}{
 			"err":       err,
 			"operation": operation.OperationName,
 		})
+		errorKind = string(signature.ValidationError)
+		var sigErr *signature.Error
+		if errors.As(err, &sigErr) {
+			errorKind = string(sigErr.Kind)
+		}
+		sign = signature.InvalidOperationSample(operation.RawQuery)
+		hash = signature.InvalidOperationHash(sign)
+	}
+
+	var staticStats signature.Stats
+	var variablesUsage signature.Usage
+	if errorKind == "" {
+		staticStats = signature.OperationStats(operation.Doc, operation.Operation)
+		variablesUsage = signature.VariablesUsage(e.schema, operation.Operation, operation.Variables)
+	}
+
+	complexity := 0
+	if complexityStats := extension.GetComplexityStats(ctx); complexityStats != nil {
+		complexity = complexityStats.Complexity
+	}
+
+	if e.safelist != nil && !e.safelist.Contains(hash) && !e.allowOperation(operation, hash, sign, errorKind == "") {
+		return e.rejectOperation(&graphmetrics.OperationMessage{
+			Name:      operation.OperationName,
+			Type:      string(operation.Operation.Operation),
+			Hash:      hash,
+			Signature: sign,
+			HasErrors: true,
+			Duration:  time.Since(operation.Stats.OperationStart),
+			Client:    caller,
+
+			Tenant:         tenant,
+			Stats:          staticStats,
+			Complexity:     complexity,
+			Usage:          usage,
+			VariablesUsage: variablesUsage,
+		}, errorKind == "")
 	}
-	hash := signature.OperationHash(sign)
 
+	if errorKind == "" && operation.Operation.Operation == ast.Subscription {
+		stats.events = &eventQueue{} // The subscription resolver is called by next
+	}
 	handler := next(ctx)
+	if errorKind == "" && operation.Operation.Operation == ast.Subscription {
+		return e.interceptSubscription(operation, hash, sign, staticStats, usage, variablesUsage, caller, handler)
+	}
+	// Incremental delivery (@defer/@stream) calls the handler until it returns nil, the first payload
+	// is reported as the operation and the total is reported once the last payload is delivered
+	payloads, firstHasErrors, nextHasErrors := 0, false, false
 	return func(ctx context.Context) *graphql.Response {
 		res := handler(ctx)
+		if res == nil {
+			if payloads > 1 && errorKind == "" {
+				e.aggregator.PushOperation(&graphmetrics.OperationMessage{
+					Name:      operation.OperationName,
+					Hash:      hash,
+					HasErrors: !firstHasErrors && nextHasErrors,
+					Payloads:  payloads,
+					Duration:  time.Since(operation.Stats.OperationStart),
+					Client:    caller,
+				})
+			}
+			return res
+		}
+
+		payloads++
+		if payloads > 1 {
+			nextHasErrors = nextHasErrors || len(res.Errors) > 0
+			return res
+		}
+		firstHasErrors = len(res.Errors) > 0
 		duration := time.Since(operation.Stats.OperationStart)
 		e.aggregator.PushOperation(&graphmetrics.OperationMessage{
 			Name:      operation.OperationName,
 			Type:      string(operation.Operation.Operation),
 			Hash:      hash,
 			Signature: sign,
-			HasErrors: len(res.Errors) > 0,
+			HasErrors: firstHasErrors,
+			ErrorKind: errorKind,
 			Duration:  duration,
 			Client:    caller,
+
+			Tenant:         tenant,
+			ResolverTime:   time.Duration(atomic.LoadInt64(&stats.resolverTime)),
+			ResponseSize:   responseSize(res),
+			Stats:          staticStats,
+			Complexity:     complexity,
+			Usage:          usage,
+			VariablesUsage: variablesUsage,
 		})
 
 		return res
 	}
 }
 
+func (e *extensionImpl) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	res := next(ctx)
+	operation := graphql.GetOperationContext(ctx)
+	stats := getOperationStats(operation)
+	if stats.dispatched || res == nil {
+		return res
+	}
+
+	// The operation failed before its execution
+	rawQuery, operationName := operation.RawQuery, operation.OperationName
+	if rawQuery == "" {
+		rawQuery, operationName = stats.rawQuery, stats.operationName
+	}
+	operationType := ""
+	if operation.Operation != nil {
+		operationType = string(operation.Operation.Operation)
+	}
+	msg := &graphmetrics.OperationMessage{
+		Name:      operationName,
+		Type:      operationType,
+		HasErrors: true,
+		Duration:  time.Since(operation.Stats.OperationStart),
+		Client:    e.clientExtractor(withClientRequest(ctx, stats)),
+	}
+	kind := errorClass(res.Errors)
+	switch {
+	case kind == persistedQueryNotFoundError && rawQuery == "":
+		// The client only sent the hash of its query, there is no document to sign
+		msg.Rejection = kind
+	case kind == complexityLimitError && e.signRejectedOperation(ctx, operation, msg, rawQuery):
+		msg.Rejection = kind
+		msg.ResponseSize = responseSize(res)
+	default:
+		msg.ErrorKind = kind
+		msg.Signature = signature.InvalidOperationSample(rawQuery)
+		msg.Hash = signature.InvalidOperationHash(msg.Signature)
+	}
+	e.aggregator.PushOperation(msg)
+
+	return res
+}
+
+// signRejectedOperation completes the message of a valid operation rejected before its execution,
+// so it is reported with the signature and hash of the operation. It returns false if it is invalid.
+func (e *extensionImpl) signRejectedOperation(ctx context.Context, operation *graphql.OperationContext, msg *graphmetrics.OperationMessage, rawQuery string) bool {
+	sign, usage, err := signature.OperationSignatureWithUsage(e.schema, rawQuery, msg.Name)
+	if err != nil || operation.Operation == nil {
+		return false
+	}
+	hash, err := signature.OperationHash(sign)
+	if err != nil {
+		return false
+	}
+	msg.Hash = hash
+	msg.Signature = sign
+	msg.Stats = signature.OperationStats(operation.Doc, operation.Operation)
+	msg.Usage = usage
+	msg.VariablesUsage = signature.VariablesUsage(e.schema, operation.Operation, operation.Variables)
+	if complexityStats := extension.GetComplexityStats(ctx); complexityStats != nil {
+		msg.Complexity = complexityStats.Complexity
+	}
+	if e.tenantExtractor != nil {
+		msg.Tenant = e.tenantExtractor(ctx)
+	}
+	return true
+}
+
 func (e *extensionImpl) InterceptField(ctx context.Context, next graphql.Resolver) (res interface{}, err error) {
 	start := time.Now()
 
 	field := graphql.GetFieldContext(ctx)
-	caller := e.clientExtractor(ctx)
+	caller := e.fieldClient(ctx)
 	res, err = next(ctx)
 	duration := time.Since(start)
-	e.aggregator.PushField(&graphmetrics.FieldMessage{
+	addResolverTime(ctx, duration)
+	if err == nil && e.schema.Subscription != nil && field.Object == e.schema.Subscription.Name {
+		res = e.timeSubscriptionEvents(ctx, res)
+	}
+	msg := &graphmetrics.FieldMessage{
 		TypeName:   field.Object,
 		FieldName:  field.Field.Name,
 		ReturnType: field.Field.Definition.Type.String(),
 		Error:      err,
 		Duration:   duration,
 		Client:     caller,
-	})
+	}
+	if err == nil && field.Field.Definition.Type.Elem != nil {
+		msg.ListLength, msg.List = listLength(res)
+	}
+	if err == nil && e.isEnum(field.Field.Definition.Type.Name()) {
+		msg.EnumValues = enumValues(field.Field.Definition.Type.Name(), res)
+	}
+	e.aggregator.PushField(msg)
 
 	return res, err
 }
 
+func (e *extensionImpl) isEnum(typeName string) bool {
+	definition := e.schema.Types[typeName]
+	return definition != nil && definition.Kind == ast.Enum
+}
+
+// timeSubscriptionEvents wraps the events channel returned by a subscription resolver
+func (e *extensionImpl) timeSubscriptionEvents(ctx context.Context, res interface{}) interface{} {
+	stats, ok := graphql.GetOperationContext(ctx).Stats.GetExtension(extensionName).(*operationStats)
+	if !ok || stats.events == nil {
+		return res
+	}
+	return timeEvents(ctx, res, stats.events)
+}
+
+func addResolverTime(ctx context.Context, duration time.Duration) {
+	if stats, ok := graphql.GetOperationContext(ctx).Stats.GetExtension(extensionName).(*operationStats); ok {
+		atomic.AddInt64(&stats.resolverTime, int64(duration))
+	}
+}
+
+// fieldClient reuses the client extracted for the operation
+func (e *extensionImpl) fieldClient(ctx context.Context) client.Details {
+	if stats, ok := graphql.GetOperationContext(ctx).Stats.GetExtension(extensionName).(*operationStats); ok && stats.dispatched {
+		return stats.client
+	}
+	return e.clientExtractor(ctx)
+}
+
+// withClientRequest completes the client request of the context with the request extensions. The headers are
+// only known if a middleware stored the request, the operation context of gqlgen v0.13 has none.
+func withClientRequest(ctx context.Context, stats *operationStats) context.Context {
+	request := client.GetRequest(ctx)
+	extensions := stats.extensions
+	if extensions == nil {
+		extensions = request.Extensions
+	}
+	return client.WithRequest(ctx, &client.Request{
+		Header:     request.Header,
+		Query:      request.Query,
+		Extensions: extensions,
+	})
+}
+
+func getOperationStats(operation *graphql.OperationContext) *operationStats {
+	stats, ok := operation.Stats.GetExtension(extensionName).(*operationStats)
+	if !ok {
+		stats = &operationStats{}
+		operation.Stats.SetExtension(extensionName, stats)
+	}
+	return stats
+}
+
 func (e *extensionImpl) Close() error {
+	if closer, ok := e.safelist.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			e.logger.Error("unable to write the safelist", map[string]interface{}{
+				"error": err,
+			})
+		}
+	}
 	return e.aggregator.Stop()
 }
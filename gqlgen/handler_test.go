@@ -0,0 +1,217 @@
+package graphmetricsgqlgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/graphmetrics/graphmetrics-go"
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+// testReport is the part of the dumped reports checked by the tests
+type testReport struct {
+	Metrics []struct {
+		Operations        map[string]*models.OperationMetrics        `json:"operations"`
+		Subscriptions     map[string]*testSubscriptionMetrics        `json:"subscriptions"`
+		InvalidOperations map[string]*models.InvalidOperationMetrics `json:"invalidOperations"`
+		Rejections        map[string]int32                           `json:"rejections"`
+	} `json:"metrics"`
+	Definitions []models.OperationDefinition `json:"operations"`
+}
+
+type testSubscriptionMetrics struct {
+	models.SubscriptionMetrics
+	EventLatencyHistogram models.Histogram `json:"eventLatencyHistogram"`
+}
+
+// newTestExtension returns an extension only dumping its reports, the reports are read once it is closed
+func newTestExtension(cfg *graphmetrics.Configuration) (Extension, func(t *testing.T) testReport) {
+	var dump bytes.Buffer
+	if cfg.Advanced == nil {
+		cfg.Advanced = &graphmetrics.AdvancedConfiguration{}
+	}
+	cfg.Advanced.DryRun = true
+	cfg.Advanced.DumpWriter = &dump
+	cfg.Advanced.DumpFormat = graphmetrics.NDJSONDump
+	ext := NewExtension(cfg)
+	return ext, func(t *testing.T) testReport {
+		assert.NoError(t, ext.Close())
+		var report testReport
+		decoder := json.NewDecoder(&dump)
+		for {
+			// The metrics and definitions documents have distinct keys
+			err := decoder.Decode(&report)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			assert.NoError(t, err)
+		}
+		assert.Len(t, report.Metrics, 1)
+		return report
+	}
+}
+
+// execute runs the operation like a transport, calling the response handler until the end of the stream
+func execute(exec *executor.Executor, params *graphql.RawParams) []*graphql.Response {
+	ctx := graphql.StartOperationTrace(context.Background())
+	rc, errs := exec.CreateOperationContext(ctx, params)
+	if errs != nil {
+		return []*graphql.Response{exec.DispatchError(graphql.WithOperationContext(ctx, rc), errs)}
+	}
+	handler, ctx := exec.DispatchOperation(ctx, rc)
+	var responses []*graphql.Response
+	for res := handler(ctx); res != nil; res = handler(ctx) {
+		responses = append(responses, res)
+	}
+	return responses
+}
+
+// newTestServer returns a gqlgen test server using the extension before the other extensions
+func newTestServer(ext Extension, extensions ...graphql.HandlerExtension) *testserver.TestServer {
+	srv := testserver.New()
+	srv.AddTransport(transport.POST{})
+	srv.Use(ext)
+	for _, e := range extensions {
+		srv.Use(e)
+	}
+	return srv
+}
+
+// post sends the JSON body to the server and returns the decoded response
+func post(t *testing.T, srv http.Handler, body string) *graphql.Response {
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+	var res graphql.Response
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return &res
+}
+
+func TestExtension_Query(t *testing.T) {
+	ext, report := newTestExtension(&graphmetrics.Configuration{})
+	srv := newTestServer(ext)
+
+	res := post(t, srv, `{"query": "query Name { name }", "operationName": "Name"}`)
+	assert.Empty(t, res.Errors)
+
+	r := report(t)
+	assert.Len(t, r.Metrics[0].Operations, 1)
+	for _, o := range r.Metrics[0].Operations {
+		assert.Equal(t, int32(1), o.Count)
+		assert.Equal(t, int32(0), o.ErrorCount)
+	}
+	assert.Len(t, r.Definitions, 1)
+	assert.Equal(t, "Name", r.Definitions[0].Name)
+}
+
+func TestExtension_ParseError(t *testing.T) {
+	ext, report := newTestExtension(&graphmetrics.Configuration{})
+	srv := newTestServer(ext)
+
+	res := post(t, srv, `{"query": "query Name { name"}`)
+	assert.NotEmpty(t, res.Errors)
+
+	r := report(t)
+	assert.Empty(t, r.Metrics[0].Operations)
+	assert.Len(t, r.Metrics[0].InvalidOperations, 1)
+	for _, i := range r.Metrics[0].InvalidOperations {
+		assert.Equal(t, string(signature.SyntaxError), i.Kind)
+		assert.Equal(t, int32(1), i.Count)
+	}
+}
+
+func TestExtension_ComplexityLimit(t *testing.T) {
+	ext, report := newTestExtension(&graphmetrics.Configuration{})
+	srv := newTestServer(ext, extension.FixedComplexityLimit(2))
+	srv.SetCalculatedComplexity(5)
+
+	res := post(t, srv, `{"query": "query Name { name }", "operationName": "Name"}`)
+	assert.Len(t, res.Errors, 1)
+
+	// The rejected operation is reported with its signature
+	r := report(t)
+	assert.Empty(t, r.Metrics[0].InvalidOperations)
+	assert.Len(t, r.Metrics[0].Operations, 1)
+	for _, o := range r.Metrics[0].Operations {
+		assert.Equal(t, int32(1), o.Count)
+		assert.Equal(t, int32(1), o.ErrorCount)
+		assert.Equal(t, map[string]int32{complexityLimitError: 1}, o.Rejections)
+	}
+	assert.Len(t, r.Definitions, 1)
+	assert.Equal(t, "Name", r.Definitions[0].Name)
+	assert.Equal(t, 5, r.Definitions[0].Complexity)
+}
+
+func TestExtension_PersistedQueryNotFound(t *testing.T) {
+	ext, report := newTestExtension(&graphmetrics.Configuration{})
+	srv := newTestServer(ext, extension.AutomaticPersistedQuery{Cache: graphql.MapCache{}})
+
+	res := post(t, srv, `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "`+strings.Repeat("a", 64)+`"}}}`)
+	assert.Len(t, res.Errors, 1)
+
+	// The client only sent the hash, the miss is counted in the context
+	r := report(t)
+	assert.Empty(t, r.Metrics[0].Operations)
+	assert.Empty(t, r.Metrics[0].InvalidOperations)
+	assert.Equal(t, map[string]int32{persistedQueryNotFoundError: 1}, r.Metrics[0].Rejections)
+}
+
+func TestExtension_IncrementalDelivery(t *testing.T) {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type Query {
+			name: String!
+			find(id: Int!): String!
+		}
+	`})
+	es := &graphql.ExecutableSchemaMock{
+		ExecFunc: func(ctx context.Context) graphql.ResponseHandler {
+			payloads := []*graphql.Response{
+				{Data: []byte(`{"name":"test"}`)},
+				{Data: []byte(`{"find":"test"}`)},
+			}
+			return func(ctx context.Context) *graphql.Response {
+				if len(payloads) == 0 {
+					return nil
+				}
+				res := payloads[0]
+				payloads = payloads[1:]
+				return res
+			}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return schema
+		},
+	}
+	ext, report := newTestExtension(&graphmetrics.Configuration{})
+	exec := executor.New(es)
+	exec.Use(ext)
+
+	responses := execute(exec, &graphql.RawParams{Query: "query Name { name find(id: 1) }", OperationName: "Name"})
+	assert.Len(t, responses, 2)
+
+	r := report(t)
+	assert.Len(t, r.Metrics[0].Operations, 1)
+	for _, o := range r.Metrics[0].Operations {
+		assert.Equal(t, int32(1), o.Count)
+		assert.Equal(t, int32(1), o.IncrementalCount)
+		assert.Equal(t, int32(2), o.PayloadCount)
+	}
+}
@@ -0,0 +1,87 @@
+package graphmetricsgqlgen
+
+import (
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/graphmetrics/graphmetrics-go"
+	"github.com/graphmetrics/graphmetrics-go/registry"
+)
+
+const (
+	notAllowedError   = "not_allowed"
+	notAllowedCode    = "OPERATION_NOT_ALLOWED"
+	notAllowedMessage = "operation not allowed"
+
+	unknownOperationLogInterval = 1 * time.Minute
+)
+
+// unknownOperations remembers the unknown operations already logged during the current interval
+type unknownOperations struct {
+	lock  sync.Mutex
+	since time.Time
+	seen  map[string]struct{}
+}
+
+// firstSeen returns true if the hash was not seen since the start of the interval
+func (u *unknownOperations) firstSeen(hash string, now time.Time) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if u.seen == nil || now.Sub(u.since) >= unknownOperationLogInterval {
+		u.seen = make(map[string]struct{})
+		u.since = now
+	}
+	if _, ok := u.seen[hash]; ok {
+		return false
+	}
+	u.seen[hash] = struct{}{}
+	return true
+}
+
+// allowOperation handles an operation unknown to the safelist, it returns false if it must be rejected.
+// Only the operations with a valid signature are learned.
+func (e *extensionImpl) allowOperation(operation *graphql.OperationContext, hash string, sign string, valid bool) bool {
+	switch e.safelistMode {
+	case registry.RejectMode:
+		return false
+	case registry.LearnMode:
+		if !valid {
+			return true
+		}
+		err := e.safelist.Register(registry.Operation{Name: operation.OperationName, Hash: hash, Signature: sign})
+		if err != nil {
+			e.logger.Error("unable to register operation", map[string]interface{}{
+				"error":     err,
+				"operation": operation.OperationName,
+			})
+		}
+	default:
+		if e.unknownOperations.firstSeen(hash, time.Now()) {
+			e.logger.Warn("unknown operation", map[string]interface{}{
+				"operation": operation.OperationName,
+				"hash":      hash,
+			})
+		}
+	}
+	return true
+}
+
+// rejectOperation reports the rejected operation and responds with an error. A valid operation is counted
+// in the not_allowed rejections of its hash, an invalid one is reported as a not_allowed invalid operation.
+func (e *extensionImpl) rejectOperation(msg *graphmetrics.OperationMessage, valid bool) graphql.ResponseHandler {
+	if valid {
+		msg.Rejection = notAllowedError
+	} else {
+		msg.ErrorKind = notAllowedError
+	}
+	e.aggregator.PushOperation(msg)
+	return graphql.OneShot(&graphql.Response{
+		Errors: gqlerror.List{{
+			Message:    notAllowedMessage,
+			Extensions: map[string]interface{}{"code": notAllowedCode},
+		}},
+	})
+}
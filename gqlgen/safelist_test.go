@@ -0,0 +1,80 @@
+package graphmetricsgqlgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphmetrics/graphmetrics-go"
+	"github.com/graphmetrics/graphmetrics-go/registry"
+)
+
+func TestSafelist_RejectMode(t *testing.T) {
+	safelist := registry.NewMemoryRegistry()
+	ext, report := newTestExtension(&graphmetrics.Configuration{Safelist: safelist, SafelistMode: registry.RejectMode})
+	srv := newTestServer(ext)
+
+	res := post(t, srv, `{"query": "query Name { name }", "operationName": "Name"}`)
+	assert.Len(t, res.Errors, 1)
+	assert.Equal(t, notAllowedCode, res.Errors[0].Extensions["code"])
+
+	// The rejection is counted on the hash of the operation, not as an invalid operation
+	r := report(t)
+	assert.Empty(t, r.Metrics[0].InvalidOperations)
+	assert.Len(t, r.Metrics[0].Operations, 1)
+	for _, o := range r.Metrics[0].Operations {
+		assert.Equal(t, int32(1), o.Count)
+		assert.Equal(t, int32(1), o.ErrorCount)
+		assert.Equal(t, map[string]int32{notAllowedError: 1}, o.Rejections)
+	}
+	assert.Len(t, r.Definitions, 1)
+	assert.Equal(t, "Name", r.Definitions[0].Name)
+	assert.Empty(t, safelist.Operations())
+}
+
+func TestSafelist_RejectModeKnownOperation(t *testing.T) {
+	safelist := registry.NewMemoryRegistry()
+	learn, _ := newTestExtension(&graphmetrics.Configuration{Safelist: safelist, SafelistMode: registry.LearnMode})
+	post(t, newTestServer(learn), `{"query": "query Name { name }", "operationName": "Name"}`)
+	assert.NoError(t, learn.Close())
+
+	ext, report := newTestExtension(&graphmetrics.Configuration{Safelist: safelist, SafelistMode: registry.RejectMode})
+	res := post(t, newTestServer(ext), `{"query": "query Name { name }", "operationName": "Name"}`)
+	assert.Empty(t, res.Errors)
+
+	r := report(t)
+	for _, o := range r.Metrics[0].Operations {
+		assert.Empty(t, o.Rejections)
+	}
+}
+
+func TestSafelist_LearnMode(t *testing.T) {
+	safelist := registry.NewMemoryRegistry()
+	ext, report := newTestExtension(&graphmetrics.Configuration{Safelist: safelist, SafelistMode: registry.LearnMode})
+	srv := newTestServer(ext)
+
+	res := post(t, srv, `{"query": "query Name { name }", "operationName": "Name"}`)
+	assert.Empty(t, res.Errors)
+	// The invalid operations are not learned
+	post(t, srv, `{"query": "query Name { name"}`)
+
+	r := report(t)
+	assert.Len(t, r.Metrics[0].Operations, 1)
+	operations := safelist.Operations()
+	assert.Len(t, operations, 1)
+	for hash := range r.Metrics[0].Operations {
+		assert.Equal(t, hash, operations[0].Hash)
+		assert.True(t, safelist.Contains(hash))
+	}
+	assert.Equal(t, "Name", operations[0].Name)
+}
+
+func TestSafelist_UnknownOperationsLoggedOnce(t *testing.T) {
+	var u unknownOperations
+	now := time.Now()
+	assert.True(t, u.firstSeen("a", now))
+	assert.False(t, u.firstSeen("a", now.Add(time.Second)))
+	assert.True(t, u.firstSeen("b", now.Add(time.Second)))
+	assert.True(t, u.firstSeen("a", now.Add(unknownOperationLogInterval)))
+}
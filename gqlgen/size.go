@@ -0,0 +1,41 @@
+package graphmetricsgqlgen
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// responseSize is the serialized size of the response, the data is already serialized so only
+// the errors and extensions are encoded again when present
+func responseSize(res *graphql.Response) int {
+	size := len(res.Data)
+	if len(res.Errors) > 0 {
+		if errors, err := json.Marshal(res.Errors); err == nil {
+			size += len(errors)
+		}
+	}
+	if len(res.Extensions) > 0 {
+		if extensions, err := json.Marshal(res.Extensions); err == nil {
+			size += len(extensions)
+		}
+	}
+	return size
+}
+
+// listLength returns the length of a list returned by a resolver, false if the result is not a list
+func listLength(res interface{}) (int, bool) {
+	v := reflect.ValueOf(res)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Len(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,111 @@
+package graphmetricsgqlgen
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/graphmetrics/graphmetrics-go"
+	"github.com/graphmetrics/graphmetrics-go/client"
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+// interceptSubscription reports the latency of every event and the lifetime of the subscription once
+// the stream ends. The latency of an event starts when it is received from the subscription resolver,
+// the wait for the next event is not included.
+func (e *extensionImpl) interceptSubscription(operation *graphql.OperationContext, hash string, sign string, staticStats signature.Stats, usage signature.Usage, variablesUsage signature.Usage, caller client.Details, handler graphql.ResponseHandler) graphql.ResponseHandler {
+	events := getOperationStats(operation).events
+	return func(ctx context.Context) *graphql.Response {
+		start := time.Now()
+		res := handler(ctx)
+		if res == nil {
+			e.aggregator.PushSubscription(&graphmetrics.SubscriptionMessage{
+				Name:           operation.OperationName,
+				Hash:           hash,
+				Signature:      sign,
+				Ended:          true,
+				Duration:       time.Since(operation.Stats.OperationStart),
+				Client:         caller,
+				Stats:          staticStats,
+				Usage:          usage,
+				VariablesUsage: variablesUsage,
+			})
+			return res
+		}
+
+		// A response without event (e.g. the resolver failed) is timed from the call of the handler
+		if received, ok := events.pop(); ok {
+			start = received
+		}
+		// The first message of the hash inserts its definition, the usage is only counted once it ends
+		e.aggregator.PushSubscription(&graphmetrics.SubscriptionMessage{
+			Name:      operation.OperationName,
+			Hash:      hash,
+			Signature: sign,
+			HasErrors: len(res.Errors) > 0,
+			Duration:  time.Since(start),
+			Client:    caller,
+			Stats:     staticStats,
+			Usage:     usage,
+		})
+		return res
+	}
+}
+
+// eventQueue holds the reception time of the events not yet responded, the events are responded in order
+type eventQueue struct {
+	lock  sync.Mutex
+	times []time.Time
+}
+
+func (q *eventQueue) push(t time.Time) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.times = append(q.times, t)
+}
+
+func (q *eventQueue) pop() (time.Time, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if len(q.times) == 0 {
+		return time.Time{}, false
+	}
+	t := q.times[0]
+	q.times = q.times[1:]
+	return t, true
+}
+
+// timeEvents forwards the events of the channel returned by the subscription resolver to a channel
+// of the same type, recording when each event is received. The forwarding stops with the subscription.
+func timeEvents(ctx context.Context, res interface{}, events *eventQueue) interface{} {
+	source := reflect.ValueOf(res)
+	if source.Kind() != reflect.Chan || source.IsNil() {
+		return res
+	}
+	forwarded := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, source.Type().Elem()), 0)
+	done := reflect.ValueOf(ctx.Done())
+	go func() {
+		defer forwarded.Close()
+		for {
+			chosen, event, ok := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: source},
+				{Dir: reflect.SelectRecv, Chan: done},
+			})
+			if chosen == 1 || !ok {
+				return
+			}
+			events.push(time.Now())
+			chosen, _, _ = reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: forwarded, Send: event},
+				{Dir: reflect.SelectRecv, Chan: done},
+			})
+			if chosen == 1 {
+				return
+			}
+		}
+	}()
+	return forwarded.Convert(source.Type()).Interface()
+}
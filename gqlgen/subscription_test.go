@@ -0,0 +1,86 @@
+package graphmetricsgqlgen
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/graphmetrics/graphmetrics-go"
+)
+
+// newSubscriptionSchema simulates the generated code of a scalar subscription: the resolver returns
+// the events channel and every response waits for the next event
+func newSubscriptionSchema(events <-chan int) graphql.ExecutableSchema {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type Query { name: String! }
+		type Subscription { tick(every: Int): Int! }
+	`})
+	return &graphql.ExecutableSchemaMock{
+		ExecFunc: func(ctx context.Context) graphql.ResponseHandler {
+			ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+				Object: "Subscription",
+				Field: graphql.CollectedField{Field: &ast.Field{
+					Name:       "tick",
+					Alias:      "tick",
+					Definition: schema.Types["Subscription"].Fields.ForName("tick"),
+				}},
+				IsMethod:   true,
+				IsResolver: true,
+			})
+			res, err := graphql.GetOperationContext(ctx).ResolverMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+				return events, nil
+			})
+			if err != nil {
+				return graphql.OneShot(graphql.ErrorResponse(ctx, err.Error()))
+			}
+			return func(ctx context.Context) *graphql.Response {
+				tick, ok := <-res.(<-chan int)
+				if !ok {
+					return nil
+				}
+				return &graphql.Response{Data: []byte(fmt.Sprintf(`{"tick":%d}`, tick))}
+			}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return schema
+		},
+	}
+}
+
+func TestSubscription_EventLatency(t *testing.T) {
+	events := make(chan int)
+	go func() {
+		defer close(events)
+		for i := 0; i < 3; i++ {
+			time.Sleep(50 * time.Millisecond) // The wait for the events is not part of their latency
+			events <- i
+		}
+	}()
+	ext, report := newTestExtension(&graphmetrics.Configuration{})
+	exec := executor.New(newSubscriptionSchema(events))
+	exec.Use(ext)
+
+	responses := execute(exec, &graphql.RawParams{Query: "subscription Ticks { tick(every: 1) }", OperationName: "Ticks"})
+	assert.Len(t, responses, 3)
+
+	r := report(t)
+	assert.Len(t, r.Metrics[0].Subscriptions, 1)
+	for _, s := range r.Metrics[0].Subscriptions {
+		assert.Equal(t, int32(1), s.Count)
+		assert.Equal(t, int32(3), s.EventCount)
+		assert.Less(t, s.EventLatencyHistogram.Max, float64(20*time.Millisecond))
+	}
+	// The definition is inserted from the first event
+	assert.Len(t, r.Definitions, 1)
+	assert.Equal(t, "Ticks", r.Definitions[0].Name)
+	assert.Equal(t, 1, r.Definitions[0].Fields)
+	assert.Equal(t, 1, r.Definitions[0].Depth)
+	assert.Equal(t, []string{"Subscription.tick(every)"}, r.Definitions[0].Arguments)
+}
@@ -1,22 +1,40 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
 
 type OperationDefinition struct {
 	Name      string `json:"name"`
 	Type      string `json:"type"`
 	Hash      string `json:"hash"`
 	Signature string `json:"signature"`
+
+	// Static metrics of the operation, with the gqlgen complexity of its first request
+	Depth      int `json:"depth"`
+	Fields     int `json:"fields"`
+	Aliases    int `json:"aliases"`
+	Fragments  int `json:"fragments"`
+	Complexity int `json:"complexity,omitempty"`
+
+	// Schema coordinates referenced by the literals of the operation
+	Arguments   []string `json:"arguments,omitempty"`
+	InputFields []string `json:"inputFields,omitempty"`
+	EnumValues  []string `json:"enumValues,omitempty"`
 }
 
 type UsageDefinitions struct {
-	Timestamp  time.Time             `json:"timestamp"`
-	Operations []OperationDefinition `json:"operations"`
+	Timestamp   time.Time             `json:"timestamp"`
+	HashVersion int                   `json:"hashVersion"`
+	Operations  []OperationDefinition `json:"operations"`
 }
 
 func NewUsageDefinitions() *UsageDefinitions {
 	return &UsageDefinitions{
-		Timestamp:  time.Time{},
-		Operations: make([]OperationDefinition, 0, 5),
+		Timestamp:   time.Time{},
+		HashVersion: signature.HashVersion,
+		Operations:  make([]OperationDefinition, 0, 5),
 	}
 }
@@ -2,9 +2,9 @@ package models
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 	"time"
-
-	"github.com/graphmetrics/sketches-go/ddsketch"
 )
 
 const (
@@ -15,92 +15,225 @@ const (
 	relativeAccuracy     = 0.01
 )
 
+// Histogram carries everything needed to rebuild the sketch and its quantiles.
+// The indexes are sent as int32 so that a mapping with a smaller accuracy never truncates them.
 type Histogram struct {
-	Indexes []int16 `json:"indexes"`
-	Counts  []int32 `json:"counts"`
+	Mapping   SketchMapping `json:"mapping"`
+	Indexes   []int32       `json:"indexes"`
+	Counts    []int32       `json:"counts"`
+	ZeroCount int32         `json:"zeroCount"`
+	Min       float64       `json:"min"`
+	Max       float64       `json:"max"`
+	Sum       float64       `json:"sum"`
 }
 
-type FieldMetrics struct {
-	ReturnType string             `json:"returnType"`
-	Count      int32              `json:"count"`
-	ErrorCount int32              `json:"errorCount"`
-	Histogram  *ddsketch.DDSketch `json:"-"`
-}
-
-func (f *FieldMetrics) MarshalJSON() ([]byte, error) {
+func newHistogram(sketch *Sketch, count int32) Histogram {
 	// extract keys and counts from histogram bins
 	// conservative size of half the count will be in the same bin
-	indexes := make([]int16, 0, f.Count/2)
-	counts := make([]int32, 0, f.Count/2)
-	for b := range f.Histogram.Bins() {
-		// we made some guarantees in the sketch so all indexes are within 16 bits
-		indexes = append(indexes, int16(b.Index()))
+	indexes := make([]int32, 0, count/2)
+	counts := make([]int32, 0, count/2)
+	for b := range sketch.Bins() {
+		indexes = append(indexes, int32(b.Index()))
 		counts = append(counts, b.Count())
 	}
+	return Histogram{
+		Mapping:   newSketchMapping(sketch.IndexMapping),
+		Indexes:   indexes,
+		Counts:    counts,
+		ZeroCount: sketch.ZeroCount,
+		Min:       sketch.Min,
+		Max:       sketch.Max,
+		Sum:       sketch.Sum,
+	}
+}
+
+// DurationStats complete the exact minimum, maximum and sum of the histogram with the count
+// and the mean of the durations, so the time spent can be ranked without the bins
+type DurationStats struct {
+	Count int32         `json:"count"`
+	Mean  time.Duration `json:"mean"`
+}
+
+func newDurationStats(sketch *Sketch) DurationStats {
+	count := sketch.Count()
+	if count == 0 {
+		return DurationStats{}
+	}
+	return DurationStats{
+		Count: count,
+		Mean:  time.Duration(sketch.Sum / float64(count)),
+	}
+}
+
+type FieldMetrics struct {
+	ReturnType string  `json:"returnType"`
+	Count      int32   `json:"count"`
+	ErrorCount int32   `json:"errorCount"`
+	Histogram  *Sketch `json:"-"`
+
+	ListLengthHistogram *Sketch `json:"-"` // Only for the fields returning a list
+}
 
+func (f *FieldMetrics) MarshalJSON() ([]byte, error) {
 	type Alias FieldMetrics
+	var listLength *Histogram
+	if f.ListLengthHistogram != nil {
+		h := newHistogram(f.ListLengthHistogram, f.ListLengthHistogram.Count())
+		listLength = &h
+	}
 	return json.Marshal(&struct {
-		Histogram Histogram
+		Histogram           Histogram
+		Duration            DurationStats `json:"duration"`
+		ListLengthHistogram *Histogram    `json:"listLengthHistogram,omitempty"`
 		*Alias
 	}{
-		Histogram: Histogram{Indexes: indexes, Counts: counts},
-		Alias:     (*Alias)(f),
+		Histogram:           newHistogram(f.Histogram, f.Count),
+		Duration:            newDurationStats(f.Histogram),
+		ListLengthHistogram: listLength,
+		Alias:               (*Alias)(f),
 	})
 }
 
 type TypeMetrics struct {
 	Fields map[string]*FieldMetrics `json:"fields"`
+
+	sketches SketchConfig
 }
 
 func (t *TypeMetrics) FindFieldMetrics(fieldName string) *FieldMetrics {
 	if v, ok := t.Fields[fieldName]; ok {
 		return v
 	} else {
-		h, _ := ddsketch.LogUnboundedDenseDDSketch(relativeAccuracy)
 		t.Fields[fieldName] = &FieldMetrics{
-			Histogram: h,
+			Histogram: t.sketches.newSketch(),
 		}
 		return t.Fields[fieldName]
 	}
 }
 
+// FindListFieldMetrics finds the metrics of a field returning a list, its list length histogram
+// is only allocated for these fields
+func (t *TypeMetrics) FindListFieldMetrics(fieldName string) *FieldMetrics {
+	f := t.FindFieldMetrics(fieldName)
+	if f.ListLengthHistogram == nil {
+		f.ListLengthHistogram = t.sketches.newSketch()
+	}
+	return f
+}
+
 type OperationMetrics struct {
-	Count      int32              `json:"count"`
-	ErrorCount int32              `json:"errorCount"`
-	Histogram  *ddsketch.DDSketch `json:"-"`
+	Count      int32   `json:"count"`
+	ErrorCount int32   `json:"errorCount"`
+	Histogram  *Sketch `json:"-"` // Time to the first payload
+
+	// Operations delivered in multiple payloads (@defer/@stream)
+	IncrementalCount int32   `json:"incrementalCount"`
+	PayloadCount     int32   `json:"payloadCount"`
+	TotalHistogram   *Sketch `json:"-"`
+
+	ResponseSizeHistogram *Sketch `json:"-"` // Bytes of the first payload
+	ComplexityHistogram   *Sketch `json:"-"` // Only with the gqlgen complexity extension
+
+	// Requests rejected before their execution per kind (e.g. complexity_limit), they are counted as errors
+	Rejections map[string]int32 `json:"rejections,omitempty"`
 }
 
 func (f *OperationMetrics) MarshalJSON() ([]byte, error) {
-	// extract keys and counts from histogram bins
-	// conservative size of half the count will be in the same bin
-	indexes := make([]int16, 0, f.Count/2)
-	counts := make([]int32, 0, f.Count/2)
-	for b := range f.Histogram.Bins() {
-		// we made some guarantees in the sketch so all indexes are within 16 bits
-		indexes = append(indexes, int16(b.Index()))
-		counts = append(counts, b.Count())
-	}
-
 	type Alias OperationMetrics
 	return json.Marshal(&struct {
-		Histogram Histogram
+		Histogram             Histogram
+		Duration              DurationStats `json:"duration"`
+		TotalHistogram        Histogram     `json:"totalHistogram"`
+		ResponseSizeHistogram Histogram     `json:"responseSizeHistogram"`
+		ComplexityHistogram   Histogram     `json:"complexityHistogram"`
 		*Alias
 	}{
-		Histogram: Histogram{Indexes: indexes, Counts: counts},
-		Alias:     (*Alias)(f),
+		Histogram:             newHistogram(f.Histogram, f.Count),
+		Duration:              newDurationStats(f.Histogram),
+		TotalHistogram:        newHistogram(f.TotalHistogram, f.IncrementalCount),
+		ResponseSizeHistogram: newHistogram(f.ResponseSizeHistogram, f.Count),
+		ComplexityHistogram:   newHistogram(f.ComplexityHistogram, f.ComplexityHistogram.Count()),
+		Alias:                 (*Alias)(f),
 	})
 }
 
+type SubscriptionMetrics struct {
+	Count                 int32   `json:"count"`
+	EventCount            int32   `json:"eventCount"`
+	EventErrorCount       int32   `json:"eventErrorCount"`
+	LifetimeHistogram     *Sketch `json:"-"`
+	EventLatencyHistogram *Sketch `json:"-"`
+}
+
+func (f *SubscriptionMetrics) MarshalJSON() ([]byte, error) {
+	type Alias SubscriptionMetrics
+	return json.Marshal(&struct {
+		LifetimeHistogram     Histogram `json:"lifetimeHistogram"`
+		EventLatencyHistogram Histogram `json:"eventLatencyHistogram"`
+		*Alias
+	}{
+		LifetimeHistogram:     newHistogram(f.LifetimeHistogram, f.Count),
+		EventLatencyHistogram: newHistogram(f.EventLatencyHistogram, f.EventCount),
+		Alias:                 (*Alias)(f),
+	})
+}
+
+type InvalidOperationMetrics struct {
+	Kind   string `json:"kind"`
+	Sample string `json:"sample"`
+	Count  int32  `json:"count"`
+}
+
 type MetricsContext struct {
-	ClientName    string `json:"clientName"`
-	ClientVersion string `json:"clientVersion"`
-	ServerVersion string `json:"serverVersion"`
+	ClientName    string            `json:"clientName"`
+	ClientVersion string            `json:"clientVersion"`
+	ServerVersion string            `json:"serverVersion"`
+	Dimensions    map[string]string `json:"dimensions,omitempty"`
+}
+
+// Key identifies the context, the dimensions are sorted
+func (c *MetricsContext) Key() string {
+	if len(c.Dimensions) == 0 {
+		return c.ClientName + "\x00" + c.ClientVersion + "\x00" + c.ServerVersion
+	}
+	var b strings.Builder
+	b.WriteString(c.ClientName)
+	b.WriteByte(0)
+	b.WriteString(c.ClientVersion)
+	b.WriteByte(0)
+	b.WriteString(c.ServerVersion)
+	dimensions := make([]string, 0, len(c.Dimensions))
+	for k := range c.Dimensions {
+		dimensions = append(dimensions, k)
+	}
+	sort.Strings(dimensions)
+	for _, k := range dimensions {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(c.Dimensions[k])
+	}
+	return b.String()
 }
 
 type ContextualizedUsageMetrics struct {
-	Context    MetricsContext               `json:"context"`
-	Types      map[string]*TypeMetrics      `json:"types"`
-	Operations map[string]*OperationMetrics `json:"operations"`
+	Context           MetricsContext                      `json:"context"`
+	Types             map[string]*TypeMetrics             `json:"types"`
+	Operations        map[string]*OperationMetrics        `json:"operations"`
+	Subscriptions     map[string]*SubscriptionMetrics     `json:"subscriptions"`
+	InvalidOperations map[string]*InvalidOperationMetrics `json:"invalidOperations"`
+
+	// Requests referencing each schema coordinate (Type.field(argument), Input.field, Enum.VALUE)
+	Arguments   map[string]int32 `json:"arguments"`
+	InputFields map[string]int32 `json:"inputFields"`
+	EnumValues  map[string]int32 `json:"enumValues"`
+	// Resolutions of enum fields returning each value
+	ReturnedEnumValues map[string]int32 `json:"returnedEnumValues"`
+
+	// Requests rejected without a document per kind (e.g. persisted_query_not_found)
+	Rejections map[string]int32 `json:"rejections,omitempty"`
+
+	sketches SketchConfig
 }
 
 func (t *ContextualizedUsageMetrics) FindTypeMetrics(typeName string) *TypeMetrics {
@@ -108,7 +241,8 @@ func (t *ContextualizedUsageMetrics) FindTypeMetrics(typeName string) *TypeMetri
 		return v
 	} else {
 		t.Types[typeName] = &TypeMetrics{
-			Fields: make(map[string]*FieldMetrics, fieldsAllocation),
+			Fields:   make(map[string]*FieldMetrics, fieldsAllocation),
+			sketches: t.sketches,
 		}
 		return t.Types[typeName]
 	}
@@ -118,43 +252,87 @@ func (t *ContextualizedUsageMetrics) FindOperationMetrics(operationHash string)
 	if v, ok := t.Operations[operationHash]; ok {
 		return v
 	} else {
-		h, _ := ddsketch.LogUnboundedDenseDDSketch(relativeAccuracy)
 		t.Operations[operationHash] = &OperationMetrics{
-			Histogram: h,
+			Histogram:      t.sketches.newSketch(),
+			TotalHistogram: t.sketches.newSketch(),
+
+			ResponseSizeHistogram: t.sketches.newSketch(),
+			ComplexityHistogram:   t.sketches.newSketch(),
 		}
 		return t.Operations[operationHash]
 	}
 }
 
+func (t *ContextualizedUsageMetrics) FindSubscriptionMetrics(operationHash string) *SubscriptionMetrics {
+	if v, ok := t.Subscriptions[operationHash]; ok {
+		return v
+	} else {
+		t.Subscriptions[operationHash] = &SubscriptionMetrics{
+			LifetimeHistogram:     t.sketches.newSketch(),
+			EventLatencyHistogram: t.sketches.newSketch(),
+		}
+		return t.Subscriptions[operationHash]
+	}
+}
+
+func (t *ContextualizedUsageMetrics) FindInvalidOperationMetrics(fingerprint string) *InvalidOperationMetrics {
+	if v, ok := t.InvalidOperations[fingerprint]; ok {
+		return v
+	} else {
+		t.InvalidOperations[fingerprint] = &InvalidOperationMetrics{}
+		return t.InvalidOperations[fingerprint]
+	}
+}
+
+// OverflowMetrics counts the messages reported as __other__ because of the cardinality limits
+type OverflowMetrics struct {
+	Contexts   int32 `json:"contexts"`
+	Operations int32 `json:"operations"`
+	Fields     int32 `json:"fields"`
+}
+
 type UsageMetrics struct {
-	Timestamp time.Time                    `json:"timestamp"`
-	Metrics   []ContextualizedUsageMetrics `json:"metrics"`
+	Timestamp time.Time                     `json:"timestamp"`
+	Metrics   []*ContextualizedUsageMetrics `json:"metrics"`
+	Tenants   map[string]*TenantMetrics     `json:"tenants,omitempty"`
+	Overflow  OverflowMetrics               `json:"overflow"`
+
+	contexts map[string]*ContextualizedUsageMetrics // Index of the metrics by context key
+	sketches SketchConfig
 }
 
-func (u *UsageMetrics) FindContextMetrics(ClientName string, ClientVersion string, ServerVersion string) *ContextualizedUsageMetrics {
-	for _, t := range u.Metrics {
-		if t.Context.ClientName == ClientName &&
-			t.Context.ClientVersion == ClientVersion &&
-			t.Context.ServerVersion == ServerVersion {
-			return &t
-		}
+func (u *UsageMetrics) FindContextMetrics(context MetricsContext) *ContextualizedUsageMetrics {
+	key := context.Key()
+	if t, ok := u.contexts[key]; ok {
+		return t
 	}
-	t := ContextualizedUsageMetrics{
-		Context: MetricsContext{
-			ClientName:    ClientName,
-			ClientVersion: ClientVersion,
-			ServerVersion: ServerVersion,
-		},
-		Types:      make(map[string]*TypeMetrics, typesAllocation),
-		Operations: make(map[string]*OperationMetrics, operationsAllocation),
+	t := &ContextualizedUsageMetrics{
+		Context:            context,
+		Types:              make(map[string]*TypeMetrics, typesAllocation),
+		Operations:         make(map[string]*OperationMetrics, operationsAllocation),
+		Subscriptions:      make(map[string]*SubscriptionMetrics),
+		InvalidOperations:  make(map[string]*InvalidOperationMetrics),
+		Arguments:          make(map[string]int32),
+		InputFields:        make(map[string]int32),
+		EnumValues:         make(map[string]int32),
+		ReturnedEnumValues: make(map[string]int32),
+		sketches:           u.sketches,
 	}
 	u.Metrics = append(u.Metrics, t)
-	return &t
+	u.contexts[key] = t
+	return t
 }
 
 func NewUsageMetrics() *UsageMetrics {
+	return NewUsageMetricsWithSketches(SketchConfig{})
+}
+
+func NewUsageMetricsWithSketches(sketches SketchConfig) *UsageMetrics {
 	return &UsageMetrics{
 		Timestamp: time.Time{},
-		Metrics:   make([]ContextualizedUsageMetrics, 0, clientsAllocation),
+		Metrics:   make([]*ContextualizedUsageMetrics, 0, clientsAllocation),
+		Tenants:   make(map[string]*TenantMetrics),
+		contexts:  make(map[string]*ContextualizedUsageMetrics, clientsAllocation),
+		sketches:  sketches,
 	}
 }
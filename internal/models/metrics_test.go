@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_FindContextMetricsKeepsUpdates(t *testing.T) {
+	metrics := NewUsageMetrics()
+	for i := 0; i < 100; i++ {
+		for c := 0; c < 10; c++ {
+			context := metrics.FindContextMetrics(givenContext(c))
+			context.FindOperationMetrics("hash").Count += 1
+		}
+	}
+
+	assert.Len(t, metrics.Metrics, 10)
+	for c, context := range metrics.Metrics {
+		assert.Equal(t, givenContext(c), context.Context)
+		assert.Equal(t, int32(100), context.Operations["hash"].Count)
+	}
+}
+
+func TestMetrics_FindContextMetricsDimensions(t *testing.T) {
+	metrics := NewUsageMetrics()
+	first := metrics.FindContextMetrics(MetricsContext{ClientName: "web", Dimensions: map[string]string{"a": "1", "b": "2"}})
+	second := metrics.FindContextMetrics(MetricsContext{ClientName: "web", Dimensions: map[string]string{"b": "2", "a": "1"}})
+	other := metrics.FindContextMetrics(MetricsContext{ClientName: "web", Dimensions: map[string]string{"a": "1"}})
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+	assert.Len(t, metrics.Metrics, 2)
+}
+
+func BenchmarkMetrics_FindContextMetrics(b *testing.B) {
+	for _, clients := range []int{1, 10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d clients", clients), func(b *testing.B) {
+			metrics := NewUsageMetrics()
+			contexts := make([]MetricsContext, clients)
+			for c := range contexts {
+				contexts[c] = givenContext(c)
+				metrics.FindContextMetrics(contexts[c])
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				metrics.FindContextMetrics(contexts[i%clients])
+			}
+		})
+	}
+}
+
+func BenchmarkMetrics_FindContextMetricsDimensions(b *testing.B) {
+	metrics := NewUsageMetrics()
+	context := MetricsContext{ClientName: "web", ClientVersion: "1.0", Dimensions: map[string]string{"region": "eu", "tier": "free"}}
+	for i := 0; i < b.N; i++ {
+		metrics.FindContextMetrics(context)
+	}
+}
+
+func givenContext(c int) MetricsContext {
+	return MetricsContext{
+		ClientName:    fmt.Sprintf("client-%d", c%7),
+		ClientVersion: fmt.Sprintf("1.%d", c),
+		ServerVersion: "1.0.0",
+	}
+}
+
+func TestDurationStats_MarshalJSON(t *testing.T) {
+	field := &FieldMetrics{Histogram: SketchConfig{}.newSketch(), Count: 2}
+	_ = field.Histogram.Add(float64(4 * time.Millisecond))
+	_ = field.Histogram.Add(float64(2 * time.Millisecond))
+
+	data, err := json.Marshal(field)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"duration":{"count":2,"mean":3000000}`)
+	assert.Contains(t, string(data), `"min":2000000,"max":4000000,"sum":6000000}`)
+
+	data, err = json.Marshal(&FieldMetrics{Histogram: SketchConfig{}.newSketch()})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"duration":{"count":0,"mean":0}`)
+}
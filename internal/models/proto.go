@@ -0,0 +1,203 @@
+package models
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/graphmetrics/graphmetrics-go/internal/models/reportingpb"
+)
+
+// The reports are converted to the types generated from reporting.proto in reportingpb, regenerate them
+// after every change of the schema.
+//go:generate protoc --go_out=../.. --go_opt=module=github.com/graphmetrics/graphmetrics-go reporting.proto
+
+// ProtoMarshaler is implemented by the reports that can be sent in protobuf
+type ProtoMarshaler interface {
+	// MarshalProto fails if a string is not valid UTF-8, the report must then be sent in JSON
+	MarshalProto() ([]byte, error)
+}
+
+func (u *UsageMetrics) MarshalProto() ([]byte, error) {
+	return proto.Marshal(u.toProto())
+}
+
+func (u *UsageDefinitions) MarshalProto() ([]byte, error) {
+	return proto.Marshal(u.toProto())
+}
+
+func (t *ContextualizedUsageMetrics) MarshalProto() ([]byte, error) {
+	return proto.Marshal(t.toProto())
+}
+
+func (u *UsageMetrics) toProto() *reportingpb.UsageMetrics {
+	m := &reportingpb.UsageMetrics{
+		Timestamp: timestamppb.New(u.Timestamp),
+		Metrics:   make([]*reportingpb.ContextualizedUsageMetrics, 0, len(u.Metrics)),
+		Tenants:   make(map[string]*reportingpb.TenantMetrics, len(u.Tenants)),
+		Overflow: &reportingpb.OverflowMetrics{
+			Contexts:   u.Overflow.Contexts,
+			Operations: u.Overflow.Operations,
+			Fields:     u.Overflow.Fields,
+		},
+	}
+	for _, c := range u.Metrics {
+		m.Metrics = append(m.Metrics, c.toProto())
+	}
+	for tenant, t := range u.Tenants {
+		m.Tenants[tenant] = t.toProto()
+	}
+	return m
+}
+
+func (u *UsageDefinitions) toProto() *reportingpb.UsageDefinitions {
+	d := &reportingpb.UsageDefinitions{
+		Timestamp:   timestamppb.New(u.Timestamp),
+		HashVersion: int32(u.HashVersion),
+		Operations:  make([]*reportingpb.OperationDefinition, 0, len(u.Operations)),
+	}
+	for _, o := range u.Operations {
+		d.Operations = append(d.Operations, o.toProto())
+	}
+	return d
+}
+
+func (o OperationDefinition) toProto() *reportingpb.OperationDefinition {
+	return &reportingpb.OperationDefinition{
+		Name:        o.Name,
+		Type:        o.Type,
+		Hash:        o.Hash,
+		Signature:   o.Signature,
+		Depth:       int32(o.Depth),
+		Fields:      int32(o.Fields),
+		Aliases:     int32(o.Aliases),
+		Fragments:   int32(o.Fragments),
+		Complexity:  int32(o.Complexity),
+		Arguments:   o.Arguments,
+		InputFields: o.InputFields,
+		EnumValues:  o.EnumValues,
+	}
+}
+
+func (t *ContextualizedUsageMetrics) toProto() *reportingpb.ContextualizedUsageMetrics {
+	m := &reportingpb.ContextualizedUsageMetrics{
+		Context: &reportingpb.MetricsContext{
+			ClientName:    t.Context.ClientName,
+			ClientVersion: t.Context.ClientVersion,
+			ServerVersion: t.Context.ServerVersion,
+			Dimensions:    t.Context.Dimensions,
+		},
+		Types:              make(map[string]*reportingpb.TypeMetrics, len(t.Types)),
+		Operations:         make(map[string]*reportingpb.OperationMetrics, len(t.Operations)),
+		Subscriptions:      make(map[string]*reportingpb.SubscriptionMetrics, len(t.Subscriptions)),
+		InvalidOperations:  make(map[string]*reportingpb.InvalidOperationMetrics, len(t.InvalidOperations)),
+		Arguments:          t.Arguments,
+		InputFields:        t.InputFields,
+		EnumValues:         t.EnumValues,
+		ReturnedEnumValues: t.ReturnedEnumValues,
+		Rejections:         t.Rejections,
+	}
+	for name, typ := range t.Types {
+		fields := make(map[string]*reportingpb.FieldMetrics, len(typ.Fields))
+		for fieldName, f := range typ.Fields {
+			fields[fieldName] = f.toProto()
+		}
+		m.Types[name] = &reportingpb.TypeMetrics{Fields: fields}
+	}
+	for hash, o := range t.Operations {
+		m.Operations[hash] = o.toProto()
+	}
+	for hash, s := range t.Subscriptions {
+		m.Subscriptions[hash] = &reportingpb.SubscriptionMetrics{
+			Count:                 s.Count,
+			EventCount:            s.EventCount,
+			EventErrorCount:       s.EventErrorCount,
+			LifetimeHistogram:     newHistogram(s.LifetimeHistogram, s.Count).toProto(),
+			EventLatencyHistogram: newHistogram(s.EventLatencyHistogram, s.EventCount).toProto(),
+		}
+	}
+	for fingerprint, i := range t.InvalidOperations {
+		m.InvalidOperations[fingerprint] = &reportingpb.InvalidOperationMetrics{
+			Kind:   i.Kind,
+			Sample: i.Sample,
+			Count:  i.Count,
+		}
+	}
+	return m
+}
+
+func (f *FieldMetrics) toProto() *reportingpb.FieldMetrics {
+	m := &reportingpb.FieldMetrics{
+		ReturnType: f.ReturnType,
+		Count:      f.Count,
+		ErrorCount: f.ErrorCount,
+		Histogram:  newHistogram(f.Histogram, f.Count).toProto(),
+		Duration:   newDurationStats(f.Histogram).toProto(),
+	}
+	if f.ListLengthHistogram != nil {
+		m.ListLengthHistogram = newHistogram(f.ListLengthHistogram, f.ListLengthHistogram.Count()).toProto()
+	}
+	return m
+}
+
+func (f *OperationMetrics) toProto() *reportingpb.OperationMetrics {
+	return &reportingpb.OperationMetrics{
+		Count:                 f.Count,
+		ErrorCount:            f.ErrorCount,
+		Histogram:             newHistogram(f.Histogram, f.Count).toProto(),
+		IncrementalCount:      f.IncrementalCount,
+		PayloadCount:          f.PayloadCount,
+		TotalHistogram:        newHistogram(f.TotalHistogram, f.IncrementalCount).toProto(),
+		Duration:              newDurationStats(f.Histogram).toProto(),
+		ResponseSizeHistogram: newHistogram(f.ResponseSizeHistogram, f.Count).toProto(),
+		ComplexityHistogram:   newHistogram(f.ComplexityHistogram, f.ComplexityHistogram.Count()).toProto(),
+		Rejections:            f.Rejections,
+	}
+}
+
+func (d DurationStats) toProto() *reportingpb.DurationStats {
+	return &reportingpb.DurationStats{
+		Count: d.Count,
+		Mean:  int64(d.Mean),
+	}
+}
+
+func (t *TenantMetrics) toProto() *reportingpb.TenantMetrics {
+	m := &reportingpb.TenantMetrics{
+		Count:        t.Count,
+		ErrorCount:   t.ErrorCount,
+		ResolverTime: int64(t.ResolverTime),
+		Operations:   make(map[string]*reportingpb.TenantOperationMetrics, len(t.Operations)),
+		CountError:   t.CountError,
+	}
+	for hash, o := range t.Operations {
+		m.Operations[hash] = &reportingpb.TenantOperationMetrics{
+			Count:        o.Count,
+			ErrorCount:   o.ErrorCount,
+			ResolverTime: int64(o.ResolverTime),
+		}
+	}
+	return m
+}
+
+func (h Histogram) toProto() *reportingpb.Histogram {
+	return &reportingpb.Histogram{
+		Indexes: h.Indexes,
+		Counts:  h.Counts,
+		Mapping: &reportingpb.SketchMapping{
+			Gamma:         h.Mapping.Gamma,
+			IndexOffset:   h.Mapping.IndexOffset,
+			Interpolation: interpolationProto[h.Mapping.Interpolation],
+		},
+		ZeroCount: h.ZeroCount,
+		Min:       h.Min,
+		Max:       h.Max,
+		Sum:       h.Sum,
+	}
+}
+
+// interpolationProto maps the interpolations to the Interpolation enum values
+var interpolationProto = map[string]reportingpb.SketchMapping_Interpolation{
+	interpolationNone:   reportingpb.SketchMapping_NONE,
+	interpolationLinear: reportingpb.SketchMapping_LINEAR,
+	interpolationCubic:  reportingpb.SketchMapping_CUBIC,
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/graphmetrics/graphmetrics-go/internal/models/reportingpb"
+)
+
+func TestProto_UsageMetrics(t *testing.T) {
+	metrics := NewUsageMetrics()
+	metrics.Timestamp = time.Unix(1600000000, 5)
+	metrics.Overflow = OverflowMetrics{Contexts: 1, Operations: 2, Fields: 3}
+	context := metrics.FindContextMetrics(MetricsContext{ClientName: "web", ClientVersion: "1.0", Dimensions: map[string]string{"region": "eu"}})
+	field := context.FindTypeMetrics("Query").FindListFieldMetrics("field")
+	field.ReturnType = "[String!]"
+	field.Count = 1
+	_ = field.Histogram.Add(float64(time.Millisecond))
+	_ = field.ListLengthHistogram.Add(3)
+	operation := context.FindOperationMetrics("hash")
+	operation.Count = 2
+	operation.ErrorCount = 1
+	_ = operation.Histogram.Add(float64(time.Second))
+	operation.Rejections = map[string]int32{"complexity_limit": 1}
+	context.FindSubscriptionMetrics("subscription").EventCount = 3
+	context.FindInvalidOperationMetrics("invalid").Kind = "syntax"
+	context.Arguments = map[string]int32{"Query.field(id)": 1}
+	context.Rejections = map[string]int32{"persisted_query_not_found": 2}
+	tenant := metrics.FindTenantMetrics("tenant", 10)
+	tenant.Count = 2
+	tenant.CountError = 1
+	tenant.FindOperationMetrics("hash").ResolverTime = time.Millisecond
+
+	decoded := &reportingpb.UsageMetrics{}
+	data, err := metrics.MarshalProto()
+	assert.NoError(t, err)
+	assert.NoError(t, proto.Unmarshal(data, decoded))
+
+	assert.Equal(t, metrics.Timestamp, decoded.Timestamp.AsTime().Local())
+	assert.Equal(t, int32(3), decoded.Overflow.Fields)
+	assert.Len(t, decoded.Metrics, 1)
+	contextualized := decoded.Metrics[0]
+	assert.Equal(t, "web", contextualized.Context.ClientName)
+	assert.Equal(t, "1.0", contextualized.Context.ClientVersion)
+	assert.Equal(t, map[string]string{"region": "eu"}, contextualized.Context.Dimensions)
+	assert.Equal(t, map[string]int32{"Query.field(id)": 1}, contextualized.Arguments)
+	assert.Equal(t, map[string]int32{"persisted_query_not_found": 2}, contextualized.Rejections)
+
+	decodedField := contextualized.Types["Query"].Fields["field"]
+	assert.Equal(t, "[String!]", decodedField.ReturnType)
+	assert.Equal(t, int32(1), decodedField.Count)
+	assert.Equal(t, []int32{int32(field.Histogram.Index(float64(time.Millisecond)))}, decodedField.Histogram.Indexes)
+	assert.Equal(t, []int32{1}, decodedField.Histogram.Counts)
+	assert.Equal(t, float64(time.Millisecond), decodedField.Histogram.Sum)
+	assert.InDelta(t, 1.01/0.99, decodedField.Histogram.Mapping.Gamma, 1e-12)
+	assert.Equal(t, int32(1), decodedField.Duration.Count)
+	assert.Equal(t, int64(time.Millisecond), decodedField.Duration.Mean)
+	assert.Equal(t, float64(3), decodedField.ListLengthHistogram.Max)
+
+	decodedOperation := contextualized.Operations["hash"]
+	assert.Equal(t, int32(2), decodedOperation.Count)
+	assert.Equal(t, int32(1), decodedOperation.ErrorCount)
+	assert.Equal(t, float64(time.Second), decodedOperation.Histogram.Min)
+	assert.Equal(t, map[string]int32{"complexity_limit": 1}, decodedOperation.Rejections)
+	assert.Equal(t, int32(3), contextualized.Subscriptions["subscription"].EventCount)
+	assert.Equal(t, "syntax", contextualized.InvalidOperations["invalid"].Kind)
+
+	assert.Equal(t, int32(2), decoded.Tenants["tenant"].Count)
+	assert.Equal(t, int32(1), decoded.Tenants["tenant"].CountError)
+	assert.Equal(t, int64(time.Millisecond), decoded.Tenants["tenant"].Operations["hash"].ResolverTime)
+}
+
+func TestProto_UsageDefinitions(t *testing.T) {
+	definitions := NewUsageDefinitions()
+	definitions.Operations = append(definitions.Operations, OperationDefinition{
+		Name:      "Query",
+		Type:      "query",
+		Hash:      "hash",
+		Signature: "query Query{field}",
+		Depth:     1,
+		Fields:    1,
+		Arguments: []string{"Query.field(id)"},
+	})
+
+	decoded := &reportingpb.UsageDefinitions{}
+	data, err := definitions.MarshalProto()
+	assert.NoError(t, err)
+	assert.NoError(t, proto.Unmarshal(data, decoded))
+
+	assert.Equal(t, int32(definitions.HashVersion), decoded.HashVersion)
+	assert.Len(t, decoded.Operations, 1)
+	assert.Equal(t, "query Query{field}", decoded.Operations[0].Signature)
+	assert.Equal(t, int32(1), decoded.Operations[0].Depth)
+	assert.Equal(t, []string{"Query.field(id)"}, decoded.Operations[0].Arguments)
+}
+
+func TestProto_InvalidUTF8(t *testing.T) {
+	metrics := NewUsageMetrics()
+	metrics.FindContextMetrics(MetricsContext{ClientName: "\xff"})
+
+	_, err := metrics.MarshalProto()
+	assert.Error(t, err)
+}
+
+func BenchmarkReport_JSON(b *testing.B) {
+	metrics := givenLargeReport()
+	b.ResetTimer()
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = json.Marshal(metrics)
+	}
+	b.ReportMetric(float64(len(data)), "bytes")
+	b.ReportMetric(float64(gzipSize(data)), "gzip-bytes")
+}
+
+func BenchmarkReport_Proto(b *testing.B) {
+	metrics := givenLargeReport()
+	b.ResetTimer()
+	var data []byte
+	for i := 0; i < b.N; i++ {
+		data, _ = metrics.MarshalProto()
+	}
+	b.ReportMetric(float64(len(data)), "bytes")
+	b.ReportMetric(float64(gzipSize(data)), "gzip-bytes")
+}
+
+// givenLargeReport returns a report of 2000 fields and 100 operations for 5 clients
+func givenLargeReport() *UsageMetrics {
+	metrics := NewUsageMetrics()
+	for c := 0; c < 5; c++ {
+		context := metrics.FindContextMetrics(givenContext(c))
+		for f := 0; f < 2000; f++ {
+			field := context.FindTypeMetrics(fmt.Sprintf("Type%d", f/20)).FindFieldMetrics(fmt.Sprintf("field%d", f%20))
+			field.ReturnType = "String!"
+			for d := 1; d <= 50; d++ {
+				_ = field.Histogram.Add(float64(time.Duration(d*f) * time.Microsecond))
+				field.Count++
+			}
+		}
+		for o := 0; o < 100; o++ {
+			operation := context.FindOperationMetrics(fmt.Sprintf("%064d", o))
+			for d := 1; d <= 50; d++ {
+				_ = operation.Histogram.Add(float64(time.Duration(d*o) * time.Millisecond))
+				operation.Count++
+			}
+		}
+	}
+	return metrics
+}
+
+func gzipSize(data []byte) int {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write(data)
+	_ = gz.Close()
+	return buf.Len()
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/graphmetrics/sketches-go/ddsketch"
+	"github.com/graphmetrics/sketches-go/ddsketch/mapping"
+	"github.com/graphmetrics/sketches-go/ddsketch/store"
+)
+
+// Interpolations of the sketch mapping, the log mapping has no interpolation
+const (
+	interpolationNone   = "none"
+	interpolationLinear = "linear"
+	interpolationCubic  = "cubic"
+	sketchIndexOffset   = 0 // The sketches are created without index offset
+)
+
+// Stores of the sketch bins
+const (
+	UnboundedStore         = "unbounded"
+	CollapsingLowestStore  = "collapsing_lowest"
+	CollapsingHighestStore = "collapsing_highest"
+	defaultMaxBins         = 2048
+)
+
+// MaxSketchValue is the largest duration the sketches must index without clamping. Since the indexes
+// are bounded to 16 bits, the accuracy cannot be finer than about 0.0005 to index it.
+const MaxSketchValue = float64(time.Hour)
+
+var (
+	errNegativeValue   = errors.New("negative values cannot be added to the sketch")
+	errEmptySketch     = errors.New("the sketch is empty")
+	errInvalidQuantile = errors.New("quantile must be between 0 and 1")
+	errInvalidBins     = errors.New("the histogram must have as many counts as indexes")
+	errInvalidAccuracy = errors.New("the relative accuracy must be between 0 and 1")
+	errAccuracyTooFine = errors.New("the relative accuracy is too fine to index an hour, it must be over 0.0005")
+	errUnknownStore    = errors.New("unknown sketch store")
+)
+
+// SketchConfig selects the accuracy and the store of the sketches, the zero value is the default config.
+// The collapsing stores bound the memory to MaxBins per sketch, collapsing the lowest (or highest)
+// bins once reached which loses the accuracy on the lowest (or highest) quantiles.
+type SketchConfig struct {
+	RelativeAccuracy float64
+	Store            string
+	MaxBins          int
+}
+
+// Validate returns an error if the accuracy cannot index MaxSketchValue or if the store is unknown
+func (c SketchConfig) Validate() error {
+	if err := validateAccuracy(c.RelativeAccuracy); c.RelativeAccuracy != 0 && err != nil {
+		return err
+	}
+	switch c.Store {
+	case "", UnboundedStore, CollapsingLowestStore, CollapsingHighestStore:
+		return nil
+	default:
+		return errUnknownStore
+	}
+}
+
+func validateAccuracy(accuracy float64) error {
+	m, err := mapping.NewLogarithmicMapping(accuracy)
+	if err != nil {
+		return errInvalidAccuracy
+	}
+	if m.MaxIndexableValue() < MaxSketchValue {
+		return errAccuracyTooFine
+	}
+	return nil
+}
+
+// newSketch uses the default accuracy if the configured one is invalid
+func (c SketchConfig) newSketch() *Sketch {
+	accuracy := c.RelativeAccuracy
+	if validateAccuracy(accuracy) != nil {
+		accuracy = relativeAccuracy
+	}
+	maxBins := c.MaxBins
+	if maxBins <= 0 {
+		maxBins = defaultMaxBins
+	}
+	var s *ddsketch.DDSketch
+	switch c.Store {
+	case CollapsingLowestStore:
+		s, _ = ddsketch.LogCollapsingLowestDenseDDSketch(accuracy, maxBins)
+	case CollapsingHighestStore:
+		s, _ = ddsketch.LogCollapsingHighestDenseDDSketch(accuracy, maxBins)
+	default:
+		s, _ = ddsketch.LogUnboundedDenseDDSketch(accuracy)
+	}
+	return &Sketch{DDSketch: s}
+}
+
+// Sketch wraps a DDSketch to keep the values too small to be indexed in a zero bucket
+// and to track the exact minimum, maximum and sum of the values. The indexes of the mapping
+// are bounded to 16 bits, the values too big to be indexed are counted in the highest bin.
+type Sketch struct {
+	*ddsketch.DDSketch
+	ZeroCount int32
+	Min       float64
+	Max       float64
+	Sum       float64
+}
+
+func (s *Sketch) Add(value float64) error {
+	if value < 0 {
+		return errNegativeValue
+	}
+	if value < s.MinIndexableValue() {
+		s.ZeroCount += 1
+	} else if err := s.DDSketch.Add(math.Min(value, s.MaxIndexableValue())); err != nil {
+		return err
+	}
+	if s.Count() == 1 || value < s.Min {
+		s.Min = value
+	}
+	if value > s.Max {
+		s.Max = value
+	}
+	s.Sum += value
+	return nil
+}
+
+// Count returns the number of values added, including the zero bucket
+func (s *Sketch) Count() int32 {
+	return s.GetCount() + s.ZeroCount
+}
+
+// Quantile returns the value at the quantile, including the zero bucket.
+// The values are bounded by the exact minimum and maximum.
+func (s *Sketch) Quantile(quantile float64) (float64, error) {
+	if quantile < 0 || quantile > 1 {
+		return 0, errInvalidQuantile
+	}
+	count := s.Count()
+	if count == 0 {
+		return 0, errEmptySketch
+	}
+	rank := quantile * float64(count-1)
+	if rank < float64(s.ZeroCount) {
+		return s.Min, nil
+	}
+	binsQuantile := 0.0
+	if s.GetCount() > 1 {
+		binsQuantile = math.Min((rank-float64(s.ZeroCount))/float64(s.GetCount()-1), 1)
+	}
+	value, err := s.GetValueAtQuantile(binsQuantile)
+	if err != nil {
+		return 0, err
+	}
+	return math.Max(s.Min, math.Min(value, s.Max)), nil
+}
+
+// Sketch rebuilds the sketch of a decoded histogram
+func (h Histogram) Sketch() (*Sketch, error) {
+	if len(h.Indexes) != len(h.Counts) {
+		return nil, errInvalidBins
+	}
+	var m mapping.IndexMapping
+	var err error
+	switch h.Mapping.Interpolation {
+	case interpolationLinear:
+		m, err = mapping.NewLinearlyInterpolatedMappingWithGamma(h.Mapping.Gamma, h.Mapping.IndexOffset)
+	case interpolationCubic:
+		m, err = mapping.NewCubicallyInterpolatedMappingWithGamma(h.Mapping.Gamma, h.Mapping.IndexOffset)
+	default:
+		m, err = mapping.NewLogarithmicMappingWithGamma(h.Mapping.Gamma, h.Mapping.IndexOffset)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sketch := ddsketch.NewDDSketch(m, store.NewDenseStore())
+	for i, index := range h.Indexes {
+		if err := sketch.AddWithCount(m.Value(int(index)), h.Counts[i]); err != nil {
+			return nil, err
+		}
+	}
+	return &Sketch{
+		DDSketch:  sketch,
+		ZeroCount: h.ZeroCount,
+		Min:       h.Min,
+		Max:       h.Max,
+		Sum:       h.Sum,
+	}, nil
+}
+
+// SketchMapping describes how the values are mapped to the indexes, so the sketch can be rebuilt
+// with ddsketch mapping.NewLogarithmicMappingWithGamma(gamma, indexOffset)
+type SketchMapping struct {
+	Gamma         float64 `json:"gamma"`
+	IndexOffset   float64 `json:"indexOffset"`
+	Interpolation string  `json:"interpolation"`
+}
+
+func newSketchMapping(m mapping.IndexMapping) SketchMapping {
+	interpolation := interpolationNone
+	switch m.(type) {
+	case *mapping.LinearlyInterpolatedMapping:
+		interpolation = interpolationLinear
+	case *mapping.CubicallyInterpolatedMapping:
+		interpolation = interpolationCubic
+	}
+	accuracy := m.RelativeAccuracy()
+	return SketchMapping{
+		Gamma:         (1 + accuracy) / (1 - accuracy),
+		IndexOffset:   sketchIndexOffset,
+		Interpolation: interpolation,
+	}
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/graphmetrics/sketches-go/ddsketch"
+	"github.com/graphmetrics/sketches-go/ddsketch/mapping"
+	"github.com/graphmetrics/sketches-go/ddsketch/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSketch_Add(t *testing.T) {
+	sketch := SketchConfig{}.newSketch()
+	assert.NoError(t, sketch.Add(0))
+	assert.NoError(t, sketch.Add(float64(3*time.Millisecond)))
+	assert.NoError(t, sketch.Add(float64(time.Millisecond)))
+	assert.Error(t, sketch.Add(-1))
+
+	assert.Equal(t, int32(3), sketch.Count())
+	assert.Equal(t, int32(1), sketch.ZeroCount)
+	assert.Equal(t, float64(0), sketch.Min)
+	assert.Equal(t, float64(3*time.Millisecond), sketch.Max)
+	assert.Equal(t, float64(4*time.Millisecond), sketch.Sum)
+}
+
+func TestHistogram_RebuildSketch(t *testing.T) {
+	sketch := SketchConfig{}.newSketch()
+	for d := 1; d <= 1000; d++ {
+		_ = sketch.Add(float64(time.Duration(d) * time.Millisecond))
+	}
+
+	var histogram Histogram
+	data, _ := json.Marshal(newHistogram(sketch, sketch.Count()))
+	assert.NoError(t, json.Unmarshal(data, &histogram))
+	assert.Equal(t, interpolationNone, histogram.Mapping.Interpolation)
+	assert.Equal(t, float64(1000*time.Millisecond), histogram.Max)
+
+	m, err := mapping.NewLogarithmicMappingWithGamma(histogram.Mapping.Gamma, histogram.Mapping.IndexOffset)
+	assert.NoError(t, err)
+	rebuilt := ddsketch.NewDDSketch(m, store.NewDenseStore())
+	for i, index := range histogram.Indexes {
+		_ = rebuilt.AddWithCount(m.Value(int(index)), histogram.Counts[i])
+	}
+	for _, q := range []float64{0.5, 0.95, 0.99} {
+		expected, _ := sketch.GetValueAtQuantile(q)
+		actual, _ := rebuilt.GetValueAtQuantile(q)
+		assert.InEpsilon(t, expected, actual, 1e-9)
+	}
+}
+
+func TestHistogram_Sketch(t *testing.T) {
+	sketch := SketchConfig{}.newSketch()
+	for i := 0; i < 10; i++ {
+		_ = sketch.Add(0)
+	}
+	for d := 1; d <= 90; d++ {
+		_ = sketch.Add(float64(time.Duration(d) * time.Millisecond))
+	}
+
+	var histogram Histogram
+	data, _ := json.Marshal(newHistogram(sketch, sketch.Count()))
+	assert.NoError(t, json.Unmarshal(data, &histogram))
+	rebuilt, err := histogram.Sketch()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(100), rebuilt.Count())
+	for _, q := range []float64{0, 0.05, 0.5, 0.95, 1} {
+		expected, _ := sketch.Quantile(q)
+		actual, err := rebuilt.Quantile(q)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, expected+1, actual+1, 1e-9, q)
+	}
+
+	p50, _ := rebuilt.Quantile(0.5)
+	assert.InEpsilon(t, float64(40*time.Millisecond), p50, 0.02)
+	p05, _ := rebuilt.Quantile(0.05)
+	assert.Equal(t, float64(0), p05)
+	p100, _ := rebuilt.Quantile(1)
+	assert.InEpsilon(t, float64(90*time.Millisecond), p100, 0.02)
+
+	_, err = Histogram{Indexes: []int32{1}}.Sketch()
+	assert.Error(t, err)
+	_, err = SketchConfig{}.newSketch().Quantile(0.5)
+	assert.Error(t, err)
+}
+
+func TestSketch_AddOverMaxIndexable(t *testing.T) {
+	sketch := SketchConfig{RelativeAccuracy: 0.0005}.newSketch()
+	assert.GreaterOrEqual(t, sketch.MaxIndexableValue(), MaxSketchValue)
+	assert.NoError(t, sketch.Add(float64(24*time.Hour)))
+
+	histogram := newHistogram(sketch, 1)
+	assert.Equal(t, []int32{int32(sketch.Index(sketch.MaxIndexableValue()))}, histogram.Indexes)
+	assert.LessOrEqual(t, histogram.Indexes[0], int32(math.MaxInt16))
+	assert.Equal(t, float64(24*time.Hour), histogram.Max)
+}
+
+func TestSketchConfig_Validate(t *testing.T) {
+	assert.NoError(t, SketchConfig{}.Validate())
+	assert.NoError(t, SketchConfig{RelativeAccuracy: 0.0005, Store: CollapsingLowestStore}.Validate())
+	assert.Equal(t, errAccuracyTooFine, SketchConfig{RelativeAccuracy: 0.0001}.Validate())
+	assert.Equal(t, errInvalidAccuracy, SketchConfig{RelativeAccuracy: 1}.Validate())
+	assert.Equal(t, errUnknownStore, SketchConfig{Store: "collapsing"}.Validate())
+
+	sketch := SketchConfig{RelativeAccuracy: 0.0001}.newSketch()
+	assert.InDelta(t, relativeAccuracy, sketch.RelativeAccuracy(), 1e-9)
+	assert.GreaterOrEqual(t, sketch.MaxIndexableValue(), MaxSketchValue)
+}
+
+func TestSketchConfig_CollapsingStores(t *testing.T) {
+	for _, store := range []string{CollapsingLowestStore, CollapsingHighestStore} {
+		sketch := SketchConfig{RelativeAccuracy: 0.001, Store: store, MaxBins: 100}.newSketch()
+		for d := 1; d <= 10000; d++ {
+			_ = sketch.Add(float64(time.Duration(d) * time.Microsecond))
+		}
+
+		bins := 0
+		for range sketch.Bins() {
+			bins++
+		}
+		assert.LessOrEqual(t, bins, 100, store)
+		assert.Equal(t, int32(10000), sketch.Count(), store)
+		assert.InDelta(t, 0.001, sketch.RelativeAccuracy(), 1e-12, store)
+	}
+}
+
+// BenchmarkSketchConfig_Memory measures the heap retained by the sketches of a schema of 2000 fields
+func BenchmarkSketchConfig_Memory(b *testing.B) {
+	configs := map[string]SketchConfig{
+		"unbounded 1%":               {},
+		"unbounded 0.1%":             {RelativeAccuracy: 0.001},
+		"collapsing lowest 1% 128":   {Store: CollapsingLowestStore, MaxBins: 128},
+		"collapsing highest 1% 128":  {Store: CollapsingHighestStore, MaxBins: 128},
+		"collapsing lowest 0.1% 256": {RelativeAccuracy: 0.001, Store: CollapsingLowestStore, MaxBins: 256},
+	}
+	for name, config := range configs {
+		b.Run(name, func(b *testing.B) {
+			var heap uint64
+			for i := 0; i < b.N; i++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				metrics := givenSchemaMetrics(config, 2000)
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				heap += after.HeapAlloc - before.HeapAlloc
+				runtime.KeepAlive(metrics)
+			}
+			b.ReportMetric(float64(heap)/float64(b.N), "heap-bytes")
+		})
+	}
+}
+
+// givenSchemaMetrics records latencies from a microsecond to a few seconds on every field of the schema
+func givenSchemaMetrics(config SketchConfig, fields int) *UsageMetrics {
+	metrics := NewUsageMetricsWithSketches(config)
+	context := metrics.FindContextMetrics(MetricsContext{ClientName: "web"})
+	for f := 0; f < fields; f++ {
+		field := context.FindTypeMetrics(fmt.Sprintf("Type%d", f/20)).FindFieldMetrics(fmt.Sprintf("field%d", f))
+		for d := 1; d < 5000000; d = d*11/10 + 1 {
+			_ = field.Histogram.Add(float64(time.Duration(d) * time.Microsecond))
+			field.Count += 1
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,26 @@
+package models
+
+// Split splits the metrics by context in reports whose estimated size is under maxSize.
+// A context bigger than maxSize is sent alone. The tenants and overflow are sent with the first report.
+func (u *UsageMetrics) Split(maxSize int, size func(*ContextualizedUsageMetrics) int) []*UsageMetrics {
+	reports := make([]*UsageMetrics, 0, 1)
+	current, currentSize := u.emptyCopy(), 0
+	current.Tenants = u.Tenants
+	current.Overflow = u.Overflow
+	for _, m := range u.Metrics {
+		s := size(m)
+		if len(current.Metrics) > 0 && currentSize+s > maxSize {
+			reports = append(reports, current)
+			current, currentSize = u.emptyCopy(), 0
+		}
+		current.Metrics = append(current.Metrics, m)
+		currentSize += s
+	}
+	return append(reports, current)
+}
+
+func (u *UsageMetrics) emptyCopy() *UsageMetrics {
+	c := NewUsageMetricsWithSketches(u.sketches)
+	c.Timestamp = u.Timestamp
+	return c
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit_ByContext(t *testing.T) {
+	metrics := NewUsageMetrics()
+	for c := 0; c < 5; c++ {
+		metrics.FindContextMetrics(givenContext(c))
+	}
+	metrics.FindTenantMetrics("tenant", 10)
+	metrics.Overflow.Fields = 3
+
+	reports := metrics.Split(25, func(*ContextualizedUsageMetrics) int { return 10 })
+
+	assert.Len(t, reports, 3)
+	assert.Len(t, reports[0].Metrics, 2)
+	assert.Len(t, reports[1].Metrics, 2)
+	assert.Len(t, reports[2].Metrics, 1)
+	assert.Contains(t, reports[0].Tenants, "tenant")
+	assert.Empty(t, reports[1].Tenants)
+	assert.Equal(t, int32(3), reports[0].Overflow.Fields)
+	assert.Equal(t, int32(0), reports[1].Overflow.Fields)
+}
+
+func TestSplit_ContextTooBig(t *testing.T) {
+	metrics := NewUsageMetrics()
+	for c := 0; c < 2; c++ {
+		metrics.FindContextMetrics(givenContext(c))
+	}
+
+	reports := metrics.Split(5, func(*ContextualizedUsageMetrics) int { return 10 })
+
+	assert.Len(t, reports, 2)
+	assert.Len(t, reports[0].Metrics, 1)
+	assert.Len(t, reports[1].Metrics, 1)
+}
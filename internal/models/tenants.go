@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+const otherTenant = "__other__"
+
+type TenantOperationMetrics struct {
+	Count        int32         `json:"count"`
+	ErrorCount   int32         `json:"errorCount"`
+	ResolverTime time.Duration `json:"resolverTime"`
+}
+
+type TenantMetrics struct {
+	Count        int32                              `json:"count"`
+	ErrorCount   int32                              `json:"errorCount"`
+	ResolverTime time.Duration                      `json:"resolverTime"`
+	Operations   map[string]*TenantOperationMetrics `json:"operations"`
+	// Upper bound of the requests of the tenant merged in __other__ before it was tracked,
+	// its requests in the interval are between Count and Count + CountError
+	CountError int32 `json:"countError,omitempty"`
+}
+
+func (t *TenantMetrics) FindOperationMetrics(operationHash string) *TenantOperationMetrics {
+	if v, ok := t.Operations[operationHash]; ok {
+		return v
+	} else {
+		t.Operations[operationHash] = &TenantOperationMetrics{}
+		return t.Operations[operationHash]
+	}
+}
+
+func (t *TenantMetrics) merge(other *TenantMetrics) {
+	t.Count += other.Count
+	t.ErrorCount += other.ErrorCount
+	t.ResolverTime += other.ResolverTime
+	for hash, o := range other.Operations {
+		operation := t.FindOperationMetrics(hash)
+		operation.Count += o.Count
+		operation.ErrorCount += o.ErrorCount
+		operation.ResolverTime += o.ResolverTime
+	}
+}
+
+func newTenantMetrics() *TenantMetrics {
+	return &TenantMetrics{
+		Operations: make(map[string]*TenantOperationMetrics, operationsAllocation),
+	}
+}
+
+// estimate is the Space-Saving count of the tenant, an upper bound of its requests in the interval
+func (t *TenantMetrics) estimate() int32 {
+	return t.Count + t.CountError
+}
+
+// FindTenantMetrics tracks the heaviest tenants with the Space-Saving algorithm, in up to maxTenants
+// tenants with __other__ included. Once the limit is reached, a new tenant takes the place of the tenant
+// with the smallest estimate, which is merged in __other__ so the totals stay exact. The new tenant
+// inherits this estimate as its CountError: any tenant with more than 1/(maxTenants-1) of the requests
+// of the interval is reported.
+func (u *UsageMetrics) FindTenantMetrics(tenant string, maxTenants int) *TenantMetrics {
+	if v, ok := u.Tenants[tenant]; ok {
+		return v
+	}
+	if maxTenants < 2 {
+		return u.otherTenantMetrics()
+	}
+	tracked := len(u.Tenants)
+	if _, ok := u.Tenants[otherTenant]; ok {
+		tracked--
+	}
+	// A place is kept for __other__
+	if tracked < maxTenants-1 {
+		u.Tenants[tenant] = newTenantMetrics()
+		return u.Tenants[tenant]
+	}
+
+	smallest := u.smallestTenant()
+	evicted := u.Tenants[smallest]
+	u.otherTenantMetrics().merge(evicted)
+	delete(u.Tenants, smallest)
+	t := newTenantMetrics()
+	t.CountError = evicted.estimate()
+	u.Tenants[tenant] = t
+	return t
+}
+
+func (u *UsageMetrics) otherTenantMetrics() *TenantMetrics {
+	other, ok := u.Tenants[otherTenant]
+	if !ok {
+		other = newTenantMetrics()
+		u.Tenants[otherTenant] = other
+	}
+	return other
+}
+
+// smallestTenant returns the tracked tenant with the smallest estimate, __other__ excluded
+func (u *UsageMetrics) smallestTenant() string {
+	smallest := ""
+	for tenant, t := range u.Tenants {
+		if tenant != otherTenant && (smallest == "" || t.estimate() < u.Tenants[smallest].estimate()) {
+			smallest = tenant
+		}
+	}
+	return smallest
+}
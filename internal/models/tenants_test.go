@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenants_TopK(t *testing.T) {
+	metrics := NewUsageMetrics()
+	for tenant, count := range map[string]int32{"big": 10, "medium": 5} {
+		metrics.FindTenantMetrics(tenant, 3).Count = count
+		metrics.FindTenantMetrics(tenant, 3).FindOperationMetrics("hash").Count = count
+	}
+
+	// The new tenant takes the place of the smallest one
+	metrics.FindTenantMetrics("new", 3).Count += 1
+
+	assert.Len(t, metrics.Tenants, 3)
+	assert.Equal(t, int32(10), metrics.Tenants["big"].Count)
+	assert.Equal(t, int32(1), metrics.Tenants["new"].Count)
+	assert.Equal(t, int32(5), metrics.Tenants["new"].CountError)
+	assert.Equal(t, int32(5), metrics.Tenants[otherTenant].Count)
+	assert.Equal(t, int32(5), metrics.Tenants[otherTenant].Operations["hash"].Count)
+	assert.NotContains(t, metrics.Tenants, "medium")
+}
+
+func TestTenants_LateHeavyTenant(t *testing.T) {
+	metrics := NewUsageMetrics()
+	requests := map[string]int32{}
+	request := func(tenant string) {
+		metrics.FindTenantMetrics(tenant, 4).Count += 1
+		requests[tenant]++
+	}
+	for i := 0; i < 5; i++ {
+		request("first")
+		request("second")
+	}
+	// Many small tenants, then a heavy tenant once the limit is reached
+	for _, tenant := range []string{"a", "b", "c", "d", "e", "f"} {
+		request(tenant)
+	}
+	for i := 0; i < 20; i++ {
+		request("late")
+		request("g" + string(rune('a'+i)))
+	}
+
+	assert.Len(t, metrics.Tenants, 4)
+	late := metrics.Tenants["late"]
+	assert.NotNil(t, late)
+	assert.LessOrEqual(t, late.Count, requests["late"])
+	assert.GreaterOrEqual(t, late.Count+late.CountError, requests["late"])
+
+	var total, reported int32
+	for _, count := range requests {
+		total += count
+	}
+	for _, tenant := range metrics.Tenants {
+		reported += tenant.Count
+	}
+	assert.Equal(t, total, reported)
+}
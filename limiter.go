@@ -0,0 +1,30 @@
+package graphmetrics
+
+// limiter bounds the number of distinct keys in an interval
+type limiter struct {
+	max  int
+	seen map[string]bool
+}
+
+func newLimiter(max int) *limiter {
+	return &limiter{
+		max:  max,
+		seen: make(map[string]bool),
+	}
+}
+
+// allow returns true if the key was already seen or if there is room for it
+func (l *limiter) allow(key string) bool {
+	if l.seen[key] {
+		return true
+	}
+	if len(l.seen) >= l.max {
+		return false
+	}
+	l.seen[key] = true
+	return true
+}
+
+func (l *limiter) reset() {
+	l.seen = make(map[string]bool)
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/graphmetrics/graphmetrics-go/client"
+	"github.com/graphmetrics/graphmetrics-go/signature"
 )
 
 type FieldMessage struct {
@@ -13,6 +14,10 @@ type FieldMessage struct {
 	Error      error
 	Duration   time.Duration
 	Client     client.Details
+
+	List       bool // Set when the field returns a list without error, its length is then in ListLength
+	ListLength int
+	EnumValues []string // Distinct enum values returned by the field (Enum.VALUE)
 }
 
 type OperationMessage struct {
@@ -21,6 +26,31 @@ type OperationMessage struct {
 	Hash      string
 	Signature string
 	HasErrors bool
+	ErrorKind string // Set when the signature could not be computed, the Signature is then a redacted sample
+	Rejection string // Set when the request is rejected before its execution, without Hash if it had no document
+	Payloads  int    // Set once an incremental delivery completes, the Duration is then the total time
 	Duration  time.Duration
 	Client    client.Details
+
+	Tenant       string
+	ResolverTime time.Duration // Sum of the resolvers durations
+	ResponseSize int           // Serialized size in bytes of the first payload
+
+	Stats          signature.Stats
+	Complexity     int             // Computed by the gqlgen complexity extension, 0 when disabled
+	Usage          signature.Usage // Schema coordinates of the literals
+	VariablesUsage signature.Usage // Input fields and enum values of the variables
+}
+
+type SubscriptionMessage struct {
+	Name           string
+	Hash           string
+	Signature      string
+	Ended          bool // When ended the Duration is the subscription lifetime, otherwise it is the latency of an event
+	HasErrors      bool
+	Duration       time.Duration
+	Client         client.Details
+	Stats          signature.Stats // Set on every message, the first one of the hash inserts the definition
+	Usage          signature.Usage
+	VariablesUsage signature.Usage // Only counted with the Usage when ended
 }
@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+// file is the content of a registry file, the hashes are only valid for their hash version
+type file struct {
+	HashVersion int         `json:"hashVersion"`
+	Operations  []Operation `json:"operations"`
+}
+
+// HashVersionError is returned for a registry file hashed with another signature.HashVersion,
+// its hashes cannot match the operations of the requests so it must not be enforced
+type HashVersionError struct {
+	Path        string
+	HashVersion int
+}
+
+func (e *HashVersionError) Error() string {
+	return fmt.Sprintf("registry %s was hashed with version %d instead of %d, it must be generated again", e.Path, e.HashVersion, signature.HashVersion)
+}
+
+// FileRegistry is a registry stored as a JSON file. In learn mode the new operations are
+// written in the background, the operations registered during a write are batched in the next one.
+type FileRegistry struct {
+	*MemoryRegistry
+	path    string
+	wg      sync.WaitGroup
+	lock    sync.Mutex // Protects the state of the writes
+	pending bool
+	writing bool
+	err     error // Last write error, returned by the next Register or Close
+}
+
+// NewFileRegistry loads the registry, a missing file is an empty registry
+func NewFileRegistry(path string) (*FileRegistry, error) {
+	operations, err := ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &FileRegistry{
+		MemoryRegistry: NewMemoryRegistry(operations...),
+		path:           path,
+	}, nil
+}
+
+// Register adds the operation in memory and schedules the write of the file, it returns the error
+// of a previous write if it failed
+func (r *FileRegistry) Register(operation Operation) error {
+	if r.Contains(operation.Hash) {
+		return nil
+	}
+	_ = r.MemoryRegistry.Register(operation)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := r.err
+	r.err = nil
+	r.pending = true
+	if !r.writing {
+		r.writing = true
+		r.wg.Add(1)
+		go r.write()
+	}
+	return err
+}
+
+func (r *FileRegistry) write() {
+	defer r.wg.Done()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for r.pending {
+		r.pending = false
+		r.lock.Unlock()
+		err := WriteFile(r.path, r.Operations())
+		r.lock.Lock()
+		if err != nil {
+			r.err = err
+		}
+	}
+	r.writing = false
+}
+
+// Close waits for the pending writes and returns the last write error
+func (r *FileRegistry) Close() error {
+	r.wg.Wait()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := r.err
+	r.err = nil
+	return err
+}
+
+// ReadFile reads the operations of a registry file, it fails with a HashVersionError
+// if the file was hashed with another version
+func ReadFile(path string) ([]Operation, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if f.HashVersion != signature.HashVersion {
+		return nil, &HashVersionError{Path: path, HashVersion: f.HashVersion}
+	}
+	return f.Operations, nil
+}
+
+// WriteFile atomically writes the operations sorted by hash with the current hash version,
+// so the file can be versioned
+func WriteFile(path string, operations []Operation) error {
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].Hash < operations[j].Hash
+	})
+	data, err := json.MarshalIndent(file{HashVersion: signature.HashVersion, Operations: operations}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(append(data, '\n'))
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
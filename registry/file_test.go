@@ -0,0 +1,64 @@
+package registry
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_LearnOperations(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "registry")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "operations.json")
+
+	registry, err := NewFileRegistry(path)
+	assert.NoError(t, err)
+	assert.False(t, registry.Contains("b"))
+	assert.NoError(t, registry.Register(Operation{Name: "Second", Hash: "b", Signature: "query Second {\n\tb\n}\n"}))
+	assert.NoError(t, registry.Register(Operation{Name: "First", Hash: "a", Signature: "query First {\n\ta\n}\n"}))
+	assert.NoError(t, registry.Close())
+
+	reloaded, err := NewFileRegistry(path)
+	assert.NoError(t, err)
+	assert.True(t, reloaded.Contains("a"))
+	assert.True(t, reloaded.Contains("b"))
+	operations, _ := ReadFile(path)
+	assert.Equal(t, "First", operations[0].Name)
+}
+
+func TestFile_InvalidFile(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "registry")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "operations.json")
+	_ = ioutil.WriteFile(path, []byte("{"), 0644)
+
+	_, err := NewFileRegistry(path)
+	assert.Error(t, err)
+}
+
+func TestFile_HashVersionMismatch(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "registry")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "operations.json")
+	_ = ioutil.WriteFile(path, []byte(`{"hashVersion": 1, "operations": [{"name": "First", "hash": "a"}]}`), 0644)
+
+	_, err := NewFileRegistry(path)
+	var versionErr *HashVersionError
+	assert.True(t, errors.As(err, &versionErr))
+	assert.Equal(t, 1, versionErr.HashVersion)
+}
+
+func TestFile_WriteError(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "registry")
+	defer os.RemoveAll(dir)
+
+	registry, err := NewFileRegistry(filepath.Join(dir, "missing", "operations.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, registry.Register(Operation{Name: "First", Hash: "a"}))
+	assert.Error(t, registry.Close())
+	assert.True(t, registry.Contains("a"))
+}
@@ -0,0 +1,67 @@
+// Package registry turns the operation signatures into a safelist of the known operations
+package registry
+
+import (
+	"sync"
+)
+
+type Mode string
+
+const (
+	LogMode    Mode = "log"    // The unknown operations are logged
+	RejectMode Mode = "reject" // The unknown operations are rejected
+	LearnMode  Mode = "learn"  // The unknown operations are registered
+)
+
+// Operation is an entry of the registry, identified by the signature.OperationHash of its signature
+type Operation struct {
+	Name      string `json:"name"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+// Registry provides the known operations, it is called concurrently by the requests
+// so Contains must be fast. Register is only called in learn mode.
+type Registry interface {
+	Contains(hash string) bool
+	Register(operation Operation) error
+}
+
+// MemoryRegistry is a registry kept in memory, it can be used to wrap a remote provider
+type MemoryRegistry struct {
+	lock       sync.RWMutex
+	operations map[string]Operation
+}
+
+func NewMemoryRegistry(operations ...Operation) *MemoryRegistry {
+	r := &MemoryRegistry{operations: make(map[string]Operation, len(operations))}
+	for _, o := range operations {
+		r.operations[o.Hash] = o
+	}
+	return r
+}
+
+func (r *MemoryRegistry) Contains(hash string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	_, ok := r.operations[hash]
+	return ok
+}
+
+func (r *MemoryRegistry) Register(operation Operation) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.operations[operation.Hash] = operation
+	return nil
+}
+
+// Operations returns a copy of the known operations
+func (r *MemoryRegistry) Operations() []Operation {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	operations := make([]Operation, 0, len(r.operations))
+	for _, o := range r.operations {
+		operations = append(operations, o)
+	}
+	return operations
+}
@@ -0,0 +1,17 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_MemoryRegistry(t *testing.T) {
+	registry := NewMemoryRegistry(Operation{Name: "MyQuery", Hash: "a"})
+
+	assert.True(t, registry.Contains("a"))
+	assert.False(t, registry.Contains("b"))
+	assert.NoError(t, registry.Register(Operation{Hash: "b"}))
+	assert.True(t, registry.Contains("b"))
+	assert.Len(t, registry.Operations(), 2)
+}
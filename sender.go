@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/graphmetrics/graphmetrics-go/internal/conversion"
 	"github.com/graphmetrics/graphmetrics-go/internal/logging"
 	"github.com/graphmetrics/graphmetrics-go/internal/models"
 	"github.com/graphmetrics/graphmetrics-go/internal/version"
 
 	"github.com/graphmetrics/logger-go"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/klauspost/compress/zstd"
 )
 
 type Sender struct {
@@ -22,6 +26,12 @@ type Sender struct {
 	wg        *sync.WaitGroup
 	apiKey    string
 	userAgent string
+	protobuf  int32 // Accessed atomically, 1 while the reports are sent in protobuf
+
+	compression Compression
+	gzipLevel   int
+	dumper      *dumper // Writes the reports locally when configured
+	dryRun      bool
 
 	metricsUrl     string
 	definitionsUrl string
@@ -37,11 +47,28 @@ func NewSender(cfg *Configuration) *Sender {
 	c.Logger = logging.NewRetryableLogger(cfg.GetLogger())
 
 	baseUrl := fmt.Sprintf("%s://%s/reporting", cfg.GetProtocol(), cfg.GetEndpoint())
+	var d *dumper
+	if cfg.Advanced != nil && (cfg.Advanced.DumpWriter != nil || cfg.Advanced.DumpFile != "") {
+		var err error
+		d, err = newDumper(cfg.Advanced.DumpWriter, cfg.Advanced.DumpFile, cfg.GetDumpFormat())
+		if err != nil {
+			cfg.GetLogger().Error("unable to open the dump file, the reports will not be dumped", map[string]interface{}{
+				"error": err,
+				"file":  cfg.Advanced.DumpFile,
+			})
+		}
+	}
 	return &Sender{
 		client:    c,
 		wg:        &sync.WaitGroup{},
 		apiKey:    cfg.ApiKey,
 		userAgent: fmt.Sprintf("sdk/go/%s", version.GetModuleVersion()),
+		protobuf:  conversion.Bool2Int(cfg.GetProtobuf()),
+
+		compression: cfg.GetCompression(),
+		gzipLevel:   cfg.GetGzipLevel(),
+		dumper:      d,
+		dryRun:      cfg.GetDryRun(),
 
 		metricsUrl:     fmt.Sprintf("%s/metrics", baseUrl),
 		definitionsUrl: fmt.Sprintf("%s/definitions", baseUrl),
@@ -51,51 +78,115 @@ func NewSender(cfg *Configuration) *Sender {
 	}
 }
 
+// payload is an uncompressed report in the encoding of the sender
+type payload struct {
+	body     []byte
+	protobuf bool
+}
+
 func (s *Sender) SendMetrics(metrics *models.UsageMetrics) {
-	s.send(metrics, s.metricsUrl)
+	s.send(metrics, nil, s.metricsUrl)
 }
 
 func (s *Sender) SendDefinitions(definitions *models.UsageDefinitions) {
-	s.send(definitions, s.definitionsUrl)
+	s.send(definitions, nil, s.definitionsUrl)
+}
+
+// sendEncodedMetrics sends metrics already encoded by encode to check their size
+func (s *Sender) sendEncodedMetrics(metrics *models.UsageMetrics, p *payload) {
+	s.send(metrics, p, s.metricsUrl)
 }
 
-func (s *Sender) send(data interface{}, url string) {
+func (s *Sender) send(data models.ProtoMarshaler, p *payload, url string) {
+	if s.dumper != nil {
+		// Dumped before sending since the report is not modified after the flush
+		if err := s.dumper.dump(data); err != nil {
+			s.logger.Error("unable to dump report", map[string]interface{}{
+				"error": err,
+				"url":   url,
+			})
+		}
+	}
+	if s.dryRun {
+		return
+	}
+
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
 
-		// Prepare payload
-		r, w := io.Pipe()
-		go func() {
-			err := marshalGzip(w, data)
-			_ = w.CloseWithError(err)
-		}()
-
-		// Send request
-		req, err := retryablehttp.NewRequest("POST", url, r)
-		if err != nil {
-			s.logger.Error("unable to create reporting request", map[string]interface{}{
-				"error": err,
-				"url":   url,
+		if p == nil {
+			p = s.encode(data)
+		}
+		status := s.post(p, url)
+		if p.protobuf && status == http.StatusUnsupportedMediaType {
+			// The endpoint does not support protobuf, fallback to json for this report and the next ones
+			s.logger.Warn("protobuf reports are not supported by the endpoint, falling back to json", map[string]interface{}{
+				"url": url,
 			})
-			return
+			atomic.StoreInt32(&s.protobuf, 0)
+			status = s.post(s.encode(data), url)
 		}
-		req.Header.Set("Content-Encoding", "gzip")
-		req.Header.Set("Content-Type", "application/json; charset=utf-8")
-		req.Header.Set("user-agent", s.userAgent)
-		req.Header.Set("x-api-key", s.apiKey)
-		_, err = s.client.Do(req)
-		if err != nil {
-			s.logger.Error("unable to send reporting request", map[string]interface{}{
-				"error": err,
-				"url":   url,
+		if status >= http.StatusBadRequest {
+			s.logger.Error("reporting request rejected", map[string]interface{}{
+				"status": status,
+				"url":    url,
 			})
 		}
 	}()
 }
 
+// post sends the report and returns the response status code, 0 if the request failed
+func (s *Sender) post(p *payload, url string) int {
+	// Prepare payload
+	r, w := io.Pipe()
+	go func() {
+		err := s.compress(w, p.body)
+		_ = w.CloseWithError(err)
+	}()
+
+	// Send request
+	req, err := retryablehttp.NewRequest("POST", url, r)
+	if err != nil {
+		s.logger.Error("unable to create reporting request", map[string]interface{}{
+			"error": err,
+			"url":   url,
+		})
+		return 0
+	}
+	if s.compression != NoCompression {
+		req.Header.Set("Content-Encoding", string(s.compression))
+	}
+	if p.protobuf {
+		req.Header.Set("Content-Type", "application/x-protobuf")
+	} else {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	req.Header.Set("user-agent", s.userAgent)
+	req.Header.Set("x-api-key", s.apiKey)
+	res, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error("unable to send reporting request", map[string]interface{}{
+			"error": err,
+			"url":   url,
+		})
+		return 0
+	}
+	_ = res.Body.Close()
+	return res.StatusCode
+}
+
 func (s *Sender) Stop() error {
 	s.logger.Debug("stopping sender", nil)
+	// The dump file is closed once the in-flight sends are done (or timed out), the last reports are dumped
+	// before their send starts
+	defer func() {
+		if err := s.closeDumper(); err != nil {
+			s.logger.Error("unable to close the dump file", map[string]interface{}{
+				"error": err,
+			})
+		}
+	}()
 
 	// Wait or timeout
 	// Note: this can create goroutine leak, but we don't care since Stop is only call on server exit
@@ -113,10 +204,55 @@ func (s *Sender) Stop() error {
 	}
 }
 
-func marshalGzip(w io.Writer, i interface{}) error {
-	gz := gzip.NewWriter(w)
-	if err := json.NewEncoder(gz).Encode(i); err != nil {
+func (s *Sender) closeDumper() error {
+	if s.dumper == nil {
+		return nil
+	}
+	return s.dumper.close()
+}
+
+// encode encodes the data in the current encoding, the reports only hold JSON compatible values.
+// A report with invalid UTF-8 strings cannot be encoded in protobuf, it is sent in JSON.
+func (s *Sender) encode(data models.ProtoMarshaler) *payload {
+	if atomic.LoadInt32(&s.protobuf) == 1 {
+		body, err := data.MarshalProto()
+		if err == nil {
+			return &payload{body: body, protobuf: true}
+		}
+		s.logger.Warn("unable to encode report in protobuf, sending it in JSON", map[string]interface{}{
+			"error": err,
+		})
+	}
+	body, _ := json.Marshal(data)
+	return &payload{body: body}
+}
+
+func (s *Sender) compress(w io.Writer, body []byte) error {
+	cw, err := s.compressor(w)
+	if err != nil {
+		return err
+	}
+	if _, err = cw.Write(body); err != nil {
 		return err
 	}
-	return gz.Close()
+	return cw.Close()
+}
+
+func (s *Sender) compressor(w io.Writer) (io.WriteCloser, error) {
+	switch s.compression {
+	case ZstdCompression:
+		return zstd.NewWriter(w)
+	case NoCompression:
+		return nopWriteCloser{w}, nil
+	default:
+		return gzip.NewWriterLevel(w, s.gzipLevel)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
 }
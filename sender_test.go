@@ -0,0 +1,205 @@
+package graphmetrics
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+)
+
+func TestSender_Compression(t *testing.T) {
+	for _, compression := range []Compression{GzipCompression, ZstdCompression, NoCompression} {
+		var body []byte
+		var encoding string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding = r.Header.Get("Content-Encoding")
+			body, _ = ioutil.ReadAll(r.Body)
+		}))
+
+		sender := NewSender(&Configuration{
+			Advanced: &AdvancedConfiguration{
+				Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+				Http:        true,
+				Compression: compression,
+			},
+		})
+		definitions := models.NewUsageDefinitions()
+		definitions.Operations = append(definitions.Operations, models.OperationDefinition{Name: "MyQuery"})
+		sender.SendDefinitions(definitions)
+		assert.NoError(t, sender.Stop())
+		server.Close()
+
+		var reader io.Reader
+		switch compression {
+		case GzipCompression:
+			assert.Equal(t, "gzip", encoding)
+			reader, _ = gzip.NewReader(strings.NewReader(string(body)))
+		case ZstdCompression:
+			assert.Equal(t, "zstd", encoding)
+			decoder, _ := zstd.NewReader(strings.NewReader(string(body)))
+			reader = decoder
+		case NoCompression:
+			assert.Equal(t, "", encoding)
+			reader = strings.NewReader(string(body))
+		}
+		decoded := models.UsageDefinitions{}
+		assert.NoError(t, json.NewDecoder(reader).Decode(&decoded), compression)
+		assert.Equal(t, "MyQuery", decoded.Operations[0].Name)
+	}
+}
+
+func TestSender_DumpDryRun(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	sender := NewSender(&Configuration{
+		Advanced: &AdvancedConfiguration{
+			Endpoint:   strings.TrimPrefix(server.URL, "http://"),
+			Http:       true,
+			DumpWriter: &dump,
+			DumpFormat: NDJSONDump,
+			DryRun:     true,
+		},
+	})
+	definitions := models.NewUsageDefinitions()
+	definitions.Operations = append(definitions.Operations, models.OperationDefinition{Name: "MyQuery"})
+	sender.SendDefinitions(definitions)
+	sender.SendMetrics(models.NewUsageMetrics())
+	assert.NoError(t, sender.Stop())
+
+	assert.Equal(t, 0, requests)
+	lines := strings.Split(strings.TrimSpace(dump.String()), "\n")
+	assert.Len(t, lines, 2)
+	decoded := models.UsageDefinitions{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &decoded))
+	assert.Equal(t, "MyQuery", decoded.Operations[0].Name)
+}
+
+func TestSender_DumpFile(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	dir, _ := ioutil.TempDir("", "dump")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "reports.json")
+	sender := NewSender(&Configuration{
+		Advanced: &AdvancedConfiguration{
+			Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+			Http:        true,
+			Compression: NoCompression,
+			DumpFile:    path,
+		},
+	})
+	definitions := models.NewUsageDefinitions()
+	definitions.Operations = append(definitions.Operations, models.OperationDefinition{Name: "MyQuery"})
+	sender.SendDefinitions(definitions)
+	assert.NoError(t, sender.Stop())
+
+	dump, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(dump), "\n  \"timestamp\"")
+	sent, dumped := models.UsageDefinitions{}, models.UsageDefinitions{}
+	assert.NoError(t, json.Unmarshal(body, &sent))
+	assert.NoError(t, json.Unmarshal(dump, &dumped))
+	assert.Equal(t, sent, dumped)
+}
+
+func TestSender_StopClosesDumpAfterSends(t *testing.T) {
+	received, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	}))
+	defer server.Close()
+
+	dir, _ := ioutil.TempDir("", "dump")
+	defer os.RemoveAll(dir)
+	sender := NewSender(&Configuration{
+		Advanced: &AdvancedConfiguration{
+			Endpoint: strings.TrimPrefix(server.URL, "http://"),
+			Http:     true,
+			DumpFile: filepath.Join(dir, "reports.json"),
+		},
+	})
+	sender.SendDefinitions(models.NewUsageDefinitions())
+	<-received
+
+	stopped := make(chan error)
+	go func() {
+		stopped <- sender.Stop()
+	}()
+	time.Sleep(50 * time.Millisecond)
+	_, err := sender.dumper.file.Write(nil)
+	assert.NoError(t, err, "the dump file is closed while a send is in flight")
+
+	close(release)
+	assert.NoError(t, <-stopped)
+	_, err = sender.dumper.file.Write(nil)
+	assert.Error(t, err)
+}
+
+func TestSender_UnknownCompression(t *testing.T) {
+	var encoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+	}))
+	defer server.Close()
+
+	sender := NewSender(&Configuration{
+		Advanced: &AdvancedConfiguration{
+			Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+			Http:        true,
+			Compression: "brotli",
+		},
+	})
+	sender.SendDefinitions(models.NewUsageDefinitions())
+	assert.NoError(t, sender.Stop())
+
+	assert.Equal(t, "gzip", encoding)
+}
+
+func TestSender_InvalidProtobufFallback(t *testing.T) {
+	var contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	sender := NewSender(&Configuration{
+		Advanced: &AdvancedConfiguration{
+			Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+			Http:        true,
+			Compression: NoCompression,
+			Protobuf:    true,
+		},
+	})
+	metrics := models.NewUsageMetrics()
+	metrics.FindContextMetrics(models.MetricsContext{ClientName: "\xff"})
+	sender.SendMetrics(metrics)
+	assert.NoError(t, sender.Stop())
+
+	assert.True(t, strings.HasPrefix(contentType, "application/json"))
+	assert.True(t, json.Valid(body))
+}
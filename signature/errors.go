@@ -0,0 +1,24 @@
+package signature
+
+import "fmt"
+
+type ErrorKind string
+
+const (
+	SyntaxError     ErrorKind = "syntax"
+	ValidationError ErrorKind = "validation"
+)
+
+// Error is returned when the signature of an operation cannot be computed
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s error: %s", e.Kind, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
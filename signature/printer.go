@@ -0,0 +1,202 @@
+package signature
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// printer is the canonical printer of the signatures. It is owned by the SDK so that upgrading
+// gqlparser never changes a signature. Any change to the output must bump the HashVersion.
+type printer struct {
+	buf    bytes.Buffer
+	indent int
+}
+
+func prettyPrint(document *ast.QueryDocument) string {
+	p := &printer{}
+	for _, o := range document.Operations {
+		p.printOperation(o)
+	}
+	for _, f := range document.Fragments {
+		p.printFragment(f)
+	}
+	return p.buf.String()
+}
+
+func (p *printer) printOperation(operation *ast.OperationDefinition) {
+	p.buf.WriteString(string(operation.Operation))
+	if operation.Name != "" {
+		p.buf.WriteString(" ")
+		p.buf.WriteString(operation.Name)
+	}
+	p.printVariableDefinitions(operation.VariableDefinitions)
+	p.printDirectives(operation.Directives)
+	p.printSelectionSet(operation.SelectionSet)
+	p.buf.WriteString("\n")
+}
+
+func (p *printer) printFragment(fragment *ast.FragmentDefinition) {
+	p.buf.WriteString("fragment ")
+	p.buf.WriteString(fragment.Name)
+	p.printVariableDefinitions(fragment.VariableDefinition)
+	p.buf.WriteString(" on ")
+	p.buf.WriteString(fragment.TypeCondition)
+	p.printDirectives(fragment.Directives)
+	p.printSelectionSet(fragment.SelectionSet)
+	p.buf.WriteString("\n")
+}
+
+func (p *printer) printVariableDefinitions(definitions ast.VariableDefinitionList) {
+	if len(definitions) == 0 {
+		return
+	}
+	p.buf.WriteString("(")
+	for i, d := range definitions {
+		if i > 0 {
+			p.buf.WriteString(", ")
+		}
+		p.buf.WriteString("$")
+		p.buf.WriteString(d.Variable)
+		p.buf.WriteString(": ")
+		p.buf.WriteString(d.Type.String())
+		if d.DefaultValue != nil {
+			p.buf.WriteString(" = ")
+			p.printValue(d.DefaultValue)
+		}
+	}
+	p.buf.WriteString(")")
+}
+
+func (p *printer) printDirectives(directives ast.DirectiveList) {
+	for _, d := range directives {
+		p.buf.WriteString(" @")
+		p.buf.WriteString(d.Name)
+		p.printArguments(d.Arguments)
+	}
+}
+
+func (p *printer) printArguments(arguments ast.ArgumentList) {
+	if len(arguments) == 0 {
+		return
+	}
+	p.buf.WriteString("(")
+	for i, a := range arguments {
+		if i > 0 {
+			p.buf.WriteString(", ")
+		}
+		p.buf.WriteString(a.Name)
+		p.buf.WriteString(": ")
+		p.printValue(a.Value)
+	}
+	p.buf.WriteString(")")
+}
+
+func (p *printer) printSelectionSet(selectionSet ast.SelectionSet) {
+	if len(selectionSet) == 0 {
+		return
+	}
+	p.buf.WriteString(" {\n")
+	p.indent++
+	for _, s := range selectionSet {
+		p.buf.WriteString(strings.Repeat("\t", p.indent))
+		p.printSelection(s)
+		p.buf.WriteString("\n")
+	}
+	p.indent--
+	p.buf.WriteString(strings.Repeat("\t", p.indent))
+	p.buf.WriteString("}")
+}
+
+func (p *printer) printSelection(selection ast.Selection) {
+	switch s := selection.(type) {
+	case *ast.Field:
+		if s.Alias != "" && s.Alias != s.Name {
+			p.buf.WriteString(s.Alias)
+			p.buf.WriteString(": ")
+		}
+		p.buf.WriteString(s.Name)
+		p.printArguments(s.Arguments)
+		p.printDirectives(s.Directives)
+		p.printSelectionSet(s.SelectionSet)
+	case *ast.FragmentSpread:
+		p.buf.WriteString("... ")
+		p.buf.WriteString(s.Name)
+		p.printDirectives(s.Directives)
+	case *ast.InlineFragment:
+		p.buf.WriteString("...")
+		if s.TypeCondition != "" {
+			p.buf.WriteString(" on ")
+			p.buf.WriteString(s.TypeCondition)
+		}
+		p.printDirectives(s.Directives)
+		p.printSelectionSet(s.SelectionSet)
+	}
+}
+
+func (p *printer) printValue(value *ast.Value) {
+	switch value.Kind {
+	case ast.Variable:
+		p.buf.WriteString("$")
+		p.buf.WriteString(value.Raw)
+	case ast.StringValue, ast.BlockValue:
+		p.buf.WriteString(quoteString(value.Raw))
+	case ast.ListValue:
+		p.buf.WriteString("[")
+		for i, c := range value.Children {
+			if i > 0 {
+				p.buf.WriteString(", ")
+			}
+			p.printValue(c.Value)
+		}
+		p.buf.WriteString("]")
+	case ast.ObjectValue:
+		p.buf.WriteString("{")
+		for i, c := range value.Children {
+			if i > 0 {
+				p.buf.WriteString(", ")
+			}
+			p.buf.WriteString(c.Name)
+			p.buf.WriteString(": ")
+			p.printValue(c.Value)
+		}
+		p.buf.WriteString("}")
+	default:
+		p.buf.WriteString(value.Raw)
+	}
+}
+
+// quoteString quotes the string with the GraphQL escapes, the block strings are printed as strings
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || r == utf8.RuneError {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/lexer"
+)
+
+func TestPrinter_Operation(t *testing.T) {
+	operation := `
+query   MyQuery($id: ID!, $x: Int = 3, $list: [ID!]) @myDirective(a: 1) {
+	field(id: $id, x: $x)
+	alias: secondField(input: {id: "1"}) @skip(if: true)
+	thirdField(input: $list)
+}
+`
+	expected := `query MyQuery($id: ID!, $x: Int = 3, $list: [ID!]) @myDirective(a: 1) {
+	field(id: $id, x: $x)
+	alias: secondField(input: {id: "1"}) @skip(if: true)
+	thirdField(input: $list)
+}
+`
+
+	_, document, _ := givenOperation(operation)
+
+	actual := prettyPrint(document)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPrinter_Fragments(t *testing.T) {
+	operation := `
+query {
+	fourthField {
+		... on MyType { fieldOne }
+		... @include(if: $x) { fieldThree }
+		...Test @skip(if: $y)
+	}
+}
+fragment Test on MyType { fieldTwo(id: "\"quoted\"") }
+`
+	expected := `query {
+	fourthField {
+		... on MyType {
+			fieldOne
+		}
+		... @include(if: $x) {
+			fieldThree
+		}
+		... Test @skip(if: $y)
+	}
+}
+fragment Test on MyType {
+	fieldTwo(id: "\"quoted\"")
+}
+`
+
+	_, document, _ := givenOperation(operation)
+
+	actual := prettyPrint(document)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPrinter_Values(t *testing.T) {
+	operation := `
+{
+	field(id: null, x: ENUM, y: -1.5e3)
+	secondField(input: {id: """block"""})
+	thirdField(input: [1, [2, 3], {a: false}])
+}
+`
+	expected := `query {
+	field(id: null, x: ENUM, y: -1.5e3)
+	secondField(input: {id: "block"})
+	thirdField(input: [1, [2, 3], {a: false}])
+}
+`
+
+	_, document, _ := givenOperation(operation)
+
+	actual := prettyPrint(document)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPrinter_QuoteString(t *testing.T) {
+	assert.Equal(t, `"a \"b\" \\ \n\t\u0001 é"`, quoteString("a \"b\" \\ \n\t\x01 é"))
+	assert.Equal(t, `"\u001F\u007F"`, quoteString("\x1f\x7f"))
+
+	// The printed strings are lexed back to the same value by GraphQL
+	for _, value := range []string{"a \"b\"\n\\c", "\x01\x1f", "é 世界 \U0001F600"} {
+		lex := lexer.New(&ast.Source{Input: quoteString(value)})
+		token, err := lex.ReadToken()
+		assert.Nil(t, err, value)
+		assert.Equal(t, value, token.Value)
+	}
+}
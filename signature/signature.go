@@ -3,37 +3,126 @@ package signature
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/lexer"
 	"github.com/vektah/gqlparser/v2/parser"
 	"github.com/vektah/gqlparser/v2/validator"
 )
 
+const maxSampleLength = 256
+
 func OperationSignature(schema *ast.Schema, operation string, operationName string) (string, error) {
+	signature, _, err := OperationSignatureWithUsage(schema, operation, operationName)
+	return signature, err
+}
+
+// OperationSignatureWithUsage also returns the arguments, input fields and enum values referenced
+// by the operation, they are collected in the same walk as the signature.
+func OperationSignatureWithUsage(schema *ast.Schema, operation string, operationName string) (string, Usage, error) {
 	// Parse the query (force a string so we don't reuse an existing document)
 	document, err := parser.ParseQuery(&ast.Source{Input: operation})
 	if err != nil {
-		return "", err
+		return "", Usage{}, &Error{Kind: SyntaxError, Err: err}
 	}
 
 	// Pre-walker
 	if operationName != "" {
+		if document.Operations.ForName(operationName) == nil {
+			return "", Usage{}, &Error{Kind: ValidationError, Err: fmt.Errorf("unknown operation %s", operationName)}
+		}
 		dropUnusedOperations(document, operationName)
 	}
+	if len(document.Operations) != 1 {
+		return "", Usage{}, &Error{Kind: ValidationError, Err: errors.New("document must contain exactly one operation")}
+	}
+
+	// The walker also walks the top-level fragments, the unused ones must not count in the usage
+	dropUnusedFragments(document)
 
 	// Walker
-	seenFragments, detectFragmentUsage := fragmentUsed()
+	usage := newUsageCollector()
 	events := &validator.Events{}
+	usage.register(events)
 	events.OnValue(hideLiterals)
-	events.OnFragmentSpread(detectFragmentUsage)
 	validator.Walk(schema, document, events)
 
-	// Post-walker
-	dropUnusedFragments(document, seenFragments)
-	return prettyPrint(document), nil
+	return prettyPrint(document), usage.usage(), nil
 }
 
-func OperationHash(operation string) string {
-	hash := sha256.Sum256([]byte(operation))
+// HashVersion identifies the algorithm used by OperationHash, it is bumped on every change to the
+// printer or the hashing so the backend can reconcile hashes across SDK upgrades.
+// Version 1 was the sha256 of the gqlparser formatter output.
+const HashVersion = 2
+
+// OperationHash hashes the tokens of the signature, so it does not depend on its whitespaces.
+// It fails if the signature cannot be lexed, a truncated token stream would collide with other operations.
+func OperationHash(operation string) (string, error) {
+	tokens, err := canonicalTokens(operation, false)
+	if err != nil {
+		return "", &Error{Kind: SyntaxError, Err: err}
+	}
+	return hashTokens(tokens), nil
+}
+
+// InvalidOperationSample is reported in place of the signature when it cannot be computed.
+// The literals are redacted and the sample truncated, tokens after a syntax error are dropped.
+func InvalidOperationSample(operation string) string {
+	sample, _ := canonicalTokens(operation, true) // The sample keeps the tokens before the error
+	return truncate(sample, maxSampleLength)
+}
+
+// truncate cuts the string to at most max bytes without splitting a UTF-8 character
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	end := max
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// InvalidOperationHash hashes a sample, it is already made of canonical tokens
+func InvalidOperationHash(sample string) string {
+	return hashTokens(sample)
+}
+
+func hashTokens(tokens string) string {
+	hash := sha256.Sum256([]byte(tokens))
 	return hex.EncodeToString(hash[:])
 }
+
+func canonicalTokens(operation string, redact bool) (string, error) {
+	var buf strings.Builder
+	lex := lexer.New(&ast.Source{Input: operation})
+	for {
+		token, err := lex.ReadToken()
+		if err != nil {
+			return buf.String(), err
+		}
+		if token.Kind == lexer.EOF {
+			return buf.String(), nil
+		}
+		if buf.Len() > 0 {
+			buf.WriteString(" ")
+		}
+		switch {
+		case redact && (token.Kind == lexer.Int || token.Kind == lexer.Float):
+			buf.WriteString("0")
+		case redact && (token.Kind == lexer.String || token.Kind == lexer.BlockString):
+			buf.WriteString(`""`)
+		case token.Kind == lexer.Name || token.Kind == lexer.Int || token.Kind == lexer.Float:
+			buf.WriteString(token.Value)
+		case token.Kind == lexer.String || token.Kind == lexer.BlockString:
+			buf.WriteString(quoteString(token.Value))
+		default:
+			buf.WriteString(token.Kind.String())
+		}
+	}
+}
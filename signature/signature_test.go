@@ -1,6 +1,8 @@
 package signature
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/vektah/gqlparser/v2/ast"
@@ -36,3 +38,67 @@ fragment Test on Query {
 
 	assert.Equal(t, expected, signature)
 }
+
+func TestSignature_HashIgnoresWhitespaces(t *testing.T) {
+	expected := givenHash("query MyQuery {\n\tfield(id: \"a b\", x: 0)\n}\n")
+
+	assert.Equal(t, expected, givenHash("query MyQuery{field(id:\"a b\",x:0)}"))
+	assert.Equal(t, expected, givenHash("  query   MyQuery {\n    field(id: \"a b\", x: 0)\n  }"))
+	assert.NotEqual(t, expected, givenHash("query MyQuery {\n\tfield(id: \"ab\", x: 0)\n}\n"))
+}
+
+func TestSignature_StableHash(t *testing.T) {
+	// Changing this value requires bumping the HashVersion
+	assert.Equal(t, 2, HashVersion)
+	assert.Equal(t, "7b82cd908482825da2a4381cdda62a1384faa0c1b4c248e086aa44aa59fb9cd8", givenHash("query { field }"))
+}
+
+func TestSignature_HashLexerError(t *testing.T) {
+	_, err := OperationHash(`query { field(id: "1) }`)
+
+	var sigErr *Error
+	assert.True(t, errors.As(err, &sigErr))
+	assert.Equal(t, SyntaxError, sigErr.Kind)
+}
+
+func TestSignature_SyntaxError(t *testing.T) {
+	schema, _ := validator.LoadSchema(&ast.Source{Input: schema})
+	_, err := OperationSignature(schema, `query { field(id: "1" `, "")
+
+	var sigErr *Error
+	assert.True(t, errors.As(err, &sigErr))
+	assert.Equal(t, SyntaxError, sigErr.Kind)
+}
+
+func TestSignature_ValidationError(t *testing.T) {
+	schema, _ := validator.LoadSchema(&ast.Source{Input: schema})
+	_, err := OperationSignature(schema, `query A { field } query B { field }`, "C")
+
+	var sigErr *Error
+	assert.True(t, errors.As(err, &sigErr))
+	assert.Equal(t, ValidationError, sigErr.Kind)
+
+	_, err = OperationSignature(schema, `query A { field } query B { field }`, "")
+	assert.True(t, errors.As(err, &sigErr))
+	assert.Equal(t, ValidationError, sigErr.Kind)
+}
+
+func TestSignature_InvalidOperationSample(t *testing.T) {
+	sample := InvalidOperationSample(`query Secret { field(id: "my-token", x: 42, y: 1.5) { ] }`)
+	assert.Equal(t, `query Secret { field ( id : "" x : 0 y : 0 ) { ] }`, sample)
+
+	sample = InvalidOperationSample(`query { field(id: "1" ` + strings.Repeat("a ", maxSampleLength))
+	assert.Len(t, sample, maxSampleLength)
+}
+
+func TestSignature_TruncateRunes(t *testing.T) {
+	assert.Equal(t, "ab", truncate("ab", 3))
+	assert.Equal(t, "a", truncate("aé", 2))
+	assert.Equal(t, "aé", truncate("aéb", 3))
+	assert.Equal(t, "a", truncate("a世界", 3))
+}
+
+func givenHash(operation string) string {
+	hash, _ := OperationHash(operation)
+	return hash
+}
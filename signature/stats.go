@@ -0,0 +1,60 @@
+package signature
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Stats are the static metrics of an operation, the fragments are expanded
+// so the fields and depth are the ones executed
+type Stats struct {
+	Depth     int // Deepest field, a top level field is at depth 1
+	Fields    int // Selected fields
+	Aliases   int // Fields with an alias different from their name
+	Fragments int // Distinct named fragments used
+}
+
+// OperationStats computes the stats of a validated operation with the fragments of its document
+func OperationStats(document *ast.QueryDocument, operation *ast.OperationDefinition) Stats {
+	w := &statsWalker{
+		fragments: document.Fragments,
+		used:      map[string]bool{},
+		visiting:  map[string]bool{},
+	}
+	w.walk(operation.SelectionSet, 1)
+	w.stats.Fragments = len(w.used)
+	return w.stats
+}
+
+type statsWalker struct {
+	stats     Stats
+	fragments ast.FragmentDefinitionList
+	used      map[string]bool
+	visiting  map[string]bool // Guard against fragment cycles in documents not validated
+}
+
+func (w *statsWalker) walk(selectionSet ast.SelectionSet, depth int) {
+	for _, s := range selectionSet {
+		switch s := s.(type) {
+		case *ast.Field:
+			w.stats.Fields++
+			if s.Alias != "" && s.Alias != s.Name {
+				w.stats.Aliases++
+			}
+			if depth > w.stats.Depth {
+				w.stats.Depth = depth
+			}
+			w.walk(s.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			w.walk(s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			fragment := w.fragments.ForName(s.Name)
+			if fragment == nil || w.visiting[s.Name] {
+				continue
+			}
+			w.used[s.Name] = true
+			w.visiting[s.Name] = true
+			w.walk(fragment.SelectionSet, depth)
+			w.visiting[s.Name] = false
+		}
+	}
+}
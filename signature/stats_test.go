@@ -0,0 +1,59 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats_OperationStats(t *testing.T) {
+	operation := `
+fragment Three on MyInterface {
+	fieldThree
+}
+fragment Four on Query {
+	fourthField {
+		...Three
+		... on MyType {
+			one: fieldOne
+			fieldTwo
+		}
+	}
+}
+query {
+	field
+	field: field
+	b: secondField
+	...Four
+	again: fourthField {
+		...Three
+	}
+}
+`
+
+	_, document, _ := givenOperation(operation)
+	stats := OperationStats(document, document.Operations[0])
+
+	assert.Equal(t, Stats{Depth: 2, Fields: 9, Aliases: 3, Fragments: 2}, stats)
+}
+
+func TestStats_FragmentCycle(t *testing.T) {
+	operation := `
+fragment A on Query {
+	field
+	...B
+}
+fragment B on Query {
+	secondField
+	...A
+}
+query {
+	...A
+}
+`
+
+	_, document, _ := givenOperation(operation)
+	stats := OperationStats(document, document.Operations[0])
+
+	assert.Equal(t, Stats{Depth: 1, Fields: 2, Aliases: 0, Fragments: 2}, stats)
+}
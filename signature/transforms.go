@@ -1,10 +1,7 @@
 package signature
 
 import (
-	"bytes"
-
 	"github.com/vektah/gqlparser/v2/ast"
-	"github.com/vektah/gqlparser/v2/formatter"
 	"github.com/vektah/gqlparser/v2/validator"
 )
 
@@ -21,17 +18,46 @@ func dropUnusedOperations(document *ast.QueryDocument, operationName string) {
 	}
 }
 
-func dropUnusedFragments(document *ast.QueryDocument, seenFragments map[string]bool) {
+// dropUnusedFragments keeps the fragments reachable from the operations, so the unused fragments are
+// neither printed nor walked
+func dropUnusedFragments(document *ast.QueryDocument) {
+	reachable := reachableFragments(document)
 	k := 0
 	for _, f := range document.Fragments {
-		if seenFragments[f.Name] {
+		if reachable[f.Name] {
 			document.Fragments[k] = f
 			k++
 		}
 	}
-	if k > 0 {
-		document.Fragments = document.Fragments[:k]
+	document.Fragments = document.Fragments[:k]
+}
+
+// reachableFragments returns the fragments spread by the operations, directly or through other fragments
+func reachableFragments(document *ast.QueryDocument) map[string]bool {
+	reachable := map[string]bool{}
+	var visit func(selectionSet ast.SelectionSet)
+	visit = func(selectionSet ast.SelectionSet) {
+		for _, selection := range selectionSet {
+			switch s := selection.(type) {
+			case *ast.Field:
+				visit(s.SelectionSet)
+			case *ast.InlineFragment:
+				visit(s.SelectionSet)
+			case *ast.FragmentSpread:
+				if reachable[s.Name] {
+					continue
+				}
+				reachable[s.Name] = true
+				if fragment := document.Fragments.ForName(s.Name); fragment != nil {
+					visit(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	for _, o := range document.Operations {
+		visit(o.SelectionSet)
 	}
+	return reachable
 }
 
 func hideLiterals(walker *validator.Walker, value *ast.Value) {
@@ -53,16 +79,3 @@ func hideLiterals(walker *validator.Walker, value *ast.Value) {
 		break
 	}
 }
-
-func fragmentUsed() (map[string]bool, func(walker *validator.Walker, fragmentSpread *ast.FragmentSpread)) {
-	seenFragments := map[string]bool{}
-	return seenFragments, func(walker *validator.Walker, fragmentSpread *ast.FragmentSpread) {
-		seenFragments[fragmentSpread.Name] = true
-	}
-}
-
-func prettyPrint(document *ast.QueryDocument) string {
-	var buf bytes.Buffer
-	formatter.NewFormatter(&buf).FormatQueryDocument(document)
-	return buf.String()
-}
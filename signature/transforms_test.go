@@ -68,29 +68,35 @@ fragment Test on Query {
 	assert.Equal(t, expected, actual)
 }
 
-func TestTransforms_SeenFragments(t *testing.T) {
+func TestTransforms_ReachableFragments(t *testing.T) {
 	operation := `
 fragment Test on MyInterface {
 	fieldTwo(id: "5")	
+	... Nested
+}
+fragment Nested on MyInterface {
+	fieldThree
+}
+fragment Unused on Query {
+	... Unreachable
+}
+fragment Unreachable on Query {
+	field
 }
 query {
 	fourthField {
 		... {
 			fieldOne
 		}
-		... Test
 		... on MyType {
-			fieldThree
+			... Test
 		}
 	}
 }
 `
-	schema, document, events := givenOperation(operation)
-	seenFragments, detectFragmentUsage := fragmentUsed()
-	events.OnFragmentSpread(detectFragmentUsage)
-	validator.Walk(schema, document, events)
+	_, document, _ := givenOperation(operation)
 
-	assert.Equal(t, seenFragments, map[string]bool{"Test": true})
+	assert.Equal(t, map[string]bool{"Test": true, "Nested": true}, reachableFragments(document))
 }
 
 func TestTransforms_DropUnusedFragments(t *testing.T) {
@@ -114,12 +120,27 @@ fragment Test on Query {
 `
 
 	_, document, _ := givenOperation(operation)
-	dropUnusedFragments(document, map[string]bool{"Test": true})
+	dropUnusedFragments(document)
 
 	actual := prettyPrint(document)
 	assert.Equal(t, expected, actual)
 }
 
+func TestTransforms_DropFragmentsWithoutSpread(t *testing.T) {
+	operation := `
+fragment Unused on Query {
+	field
+}
+query {
+	secondField
+}
+`
+	_, document, _ := givenOperation(operation)
+	dropUnusedFragments(document)
+
+	assert.Equal(t, "query {\n\tsecondField\n}\n", prettyPrint(document))
+}
+
 func givenOperation(operation string) (*ast.Schema, *ast.QueryDocument, *validator.Events) {
 	schema, _ := validator.LoadSchema(&ast.Source{Input: schema})
 	document, _ := parser.ParseQuery(&ast.Source{Input: operation})
@@ -149,6 +170,13 @@ type MyType implements MyInterface{
 
 input MyInput {
 	id: ID
+	filter: MyFilter
+	color: Color
+}
+
+enum Color {
+	RED
+	GREEN
 }
 
 type Query {
@@ -156,6 +184,8 @@ type Query {
 	secondField(input: MyInput): ID
 	thirdField(input: [ID]): Int
 	fourthField: MyInterface
+	fifthField(color: Color, input: MyInput): [Color]
+	sixthField(colors: [Color]): Int
 }
 
 schema {
@@ -0,0 +1,80 @@
+package signature
+
+import (
+	"sort"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Usage holds the schema coordinates referenced by an operation, sorted and without duplicates.
+// Only the literals are known statically, an input object or enum sent in a variable is not included.
+type Usage struct {
+	Arguments   []string // Type.field(argument)
+	InputFields []string // Input.field
+	EnumValues  []string // Enum.VALUE
+}
+
+type usageCollector struct {
+	arguments   map[string]bool
+	inputFields map[string]bool
+	enumValues  map[string]bool
+}
+
+func newUsageCollector() *usageCollector {
+	return &usageCollector{
+		arguments:   map[string]bool{},
+		inputFields: map[string]bool{},
+		enumValues:  map[string]bool{},
+	}
+}
+
+// register must be called before the literals are hidden since the values observers
+// are called in their registration order
+func (c *usageCollector) register(events *validator.Events) {
+	events.OnField(c.collectArguments)
+	events.OnValue(c.collectValue)
+}
+
+func (c *usageCollector) collectArguments(walker *validator.Walker, field *ast.Field) {
+	if field.ObjectDefinition == nil {
+		return
+	}
+	for _, arg := range field.Arguments {
+		c.arguments[field.ObjectDefinition.Name+"."+field.Name+"("+arg.Name+")"] = true
+	}
+}
+
+func (c *usageCollector) collectValue(walker *validator.Walker, value *ast.Value) {
+	if value.Definition == nil {
+		return
+	}
+	switch value.Kind {
+	case ast.ObjectValue:
+		for _, child := range value.Children {
+			c.inputFields[value.Definition.Name+"."+child.Name] = true
+		}
+	case ast.EnumValue:
+		c.enumValues[value.Definition.Name+"."+value.Raw] = true
+	}
+}
+
+func (c *usageCollector) usage() Usage {
+	return Usage{
+		Arguments:   sortedKeys(c.arguments),
+		InputFields: sortedKeys(c.inputFields),
+		EnumValues:  sortedKeys(c.enumValues),
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,63 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+func TestUsage_OperationSignatureWithUsage(t *testing.T) {
+	operation := `
+fragment Test on Query {
+	secondField(input: { id: "2", filter: { n: 1 } })
+}
+query MyQuery($id: ID) {
+	field(id: $id, x: 1)
+	...Test
+	fifthField(color: RED, input: { color: GREEN })
+}
+`
+	schema, _ := validator.LoadSchema(&ast.Source{Input: schema})
+	signature, usage, err := OperationSignatureWithUsage(schema, operation, "MyQuery")
+
+	assert.NoError(t, err)
+	assert.Contains(t, signature, `fifthField(color: RED, input: {})`)
+	assert.Equal(t, Usage{
+		Arguments:   []string{"Query.field(id)", "Query.field(x)", "Query.fifthField(color)", "Query.fifthField(input)", "Query.secondField(input)"},
+		InputFields: []string{"MyFilter.n", "MyInput.color", "MyInput.filter", "MyInput.id"},
+		EnumValues:  []string{"Color.GREEN", "Color.RED"},
+	}, usage)
+}
+
+func TestUsage_SelectedOperationOnly(t *testing.T) {
+	operation := `
+fragment First on Query {
+	field(x: 1)
+	... Shared
+}
+fragment Second on Query {
+	secondField(input: { color: RED })
+	... Shared
+}
+fragment Shared on Query {
+	sixthField(colors: [GREEN])
+}
+query FirstQuery {
+	... First
+}
+query SecondQuery {
+	... Second
+}
+`
+	schema, _ := validator.LoadSchema(&ast.Source{Input: schema})
+	signature, usage, err := OperationSignatureWithUsage(schema, operation, "FirstQuery")
+
+	assert.NoError(t, err)
+	assert.NotContains(t, signature, "Second")
+	assert.Equal(t, Usage{
+		Arguments:  []string{"Query.field(x)", "Query.sixthField(colors)"},
+		EnumValues: []string{"Color.GREEN"},
+	}, usage)
+}
@@ -0,0 +1,92 @@
+package signature
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// VariablesUsage returns the input fields and enum values sent in the variables of an operation.
+// The variables must be the ones coerced by the server, input objects as maps and lists as slices.
+func VariablesUsage(schema *ast.Schema, operation *ast.OperationDefinition, variables map[string]interface{}) Usage {
+	c := newUsageCollector()
+	for _, definition := range operation.VariableDefinitions {
+		if value, ok := variables[definition.Variable]; ok {
+			c.collectVariable(schema, definition.Type, value)
+		}
+	}
+	return c.usage()
+}
+
+func (c *usageCollector) collectVariable(schema *ast.Schema, typ *ast.Type, value interface{}) {
+	if value == nil {
+		return
+	}
+	if typ.Elem != nil {
+		if list, ok := value.([]interface{}); ok {
+			for _, v := range list {
+				c.collectVariable(schema, typ.Elem, v)
+			}
+			return
+		}
+		// A single value is coerced to a list of one element
+		c.collectVariable(schema, typ.Elem, value)
+		return
+	}
+	definition := schema.Types[typ.NamedType]
+	if definition == nil {
+		return
+	}
+	switch definition.Kind {
+	case ast.Enum:
+		if v, ok := value.(string); ok {
+			c.enumValues[definition.Name+"."+v] = true
+		}
+	case ast.InputObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for name, v := range object {
+			field := definition.Fields.ForName(name)
+			if field == nil {
+				continue
+			}
+			c.inputFields[definition.Name+"."+name] = true
+			c.collectVariable(schema, field.Type, v)
+		}
+	}
+}
+
+// Merge returns the union of both usages
+func (u Usage) Merge(other Usage) Usage {
+	return Usage{
+		Arguments:   mergeSorted(u.Arguments, other.Arguments),
+		InputFields: mergeSorted(u.InputFields, other.InputFields),
+		EnumValues:  mergeSorted(u.EnumValues, other.EnumValues),
+	}
+}
+
+func mergeSorted(a []string, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+	merged := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			merged = append(merged, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			merged = append(merged, b[j])
+			j++
+		default:
+			merged = append(merged, a[i])
+			i++
+			j++
+		}
+	}
+	return merged
+}
@@ -0,0 +1,39 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+func TestVariables_VariablesUsage(t *testing.T) {
+	operation := `
+query MyQuery($color: Color, $input: MyInput, $colors: [Color]) {
+	fifthField(color: $color, input: $input)
+	sixthField(colors: $colors)
+}
+`
+	schema, document, _ := givenOperation(operation)
+	usage := VariablesUsage(schema, document.Operations[0], map[string]interface{}{
+		"color":  "RED",
+		"input":  map[string]interface{}{"filter": map[string]interface{}{"n": 1}, "color": nil},
+		"colors": []interface{}{"GREEN", "RED"},
+	})
+
+	assert.Equal(t, Usage{
+		InputFields: []string{"MyFilter.n", "MyInput.color", "MyInput.filter"},
+		EnumValues:  []string{"Color.GREEN", "Color.RED"},
+	}, usage)
+}
+
+func TestVariables_Merge(t *testing.T) {
+	schema, _ := validator.LoadSchema(&ast.Source{Input: schema})
+	_, static, _ := OperationSignatureWithUsage(schema, `query ($c: Color) { fifthField(color: RED, input: { color: $c }) }`, "")
+	usage := static.Merge(Usage{EnumValues: []string{"Color.GREEN", "Color.RED"}})
+
+	assert.Equal(t, []string{"Query.fifthField(color)", "Query.fifthField(input)"}, usage.Arguments)
+	assert.Equal(t, []string{"MyInput.color"}, usage.InputFields)
+	assert.Equal(t, []string{"Color.GREEN", "Color.RED"}, usage.EnumValues)
+}
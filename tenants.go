@@ -0,0 +1,18 @@
+package graphmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+)
+
+type TenantMetrics = models.TenantMetrics
+type TenantOperationMetrics = models.TenantOperationMetrics
+
+// TenantExtractor returns the tenant of the operation, the usage is not tracked per tenant if empty
+type TenantExtractor func(context.Context) string
+
+// TenantExporter receives the usage of the tenants at every flush (indexed by tenant then operation hash).
+// It is called from the aggregator so it must not block, and the metrics must not be modified.
+type TenantExporter func(timestamp time.Time, tenants map[string]*TenantMetrics)
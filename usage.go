@@ -0,0 +1,42 @@
+package graphmetrics
+
+import (
+	"time"
+
+	"github.com/graphmetrics/graphmetrics-go/checker"
+	"github.com/graphmetrics/graphmetrics-go/internal/models"
+)
+
+// UsageExporter receives the schema usage per client at every flush, it can be saved (e.g. as JSON)
+// to check the schema changes against the real traffic with the checker package.
+// It is called from the aggregator so it must not block.
+type UsageExporter func(timestamp time.Time, usage *checker.Usage)
+
+// schemaUsage converts the metrics of an interval, the fields over the limits are exported as overflowed
+// so the checker does not consider their coordinates unused
+func schemaUsage(metrics *models.UsageMetrics) *checker.Usage {
+	usage := checker.NewUsage()
+	for _, m := range metrics.Metrics {
+		c := usage.FindClientUsage(m.Context.ClientName + " " + m.Context.ClientVersion)
+		for typeName, t := range m.Types {
+			for fieldName, f := range t.Fields {
+				if typeName == otherValue {
+					c.OverflowedFields += int64(f.Count)
+					continue
+				}
+				c.Fields[typeName+"."+fieldName] += int64(f.Count)
+			}
+		}
+		addCounts(c.Arguments, m.Arguments)
+		addCounts(c.InputFields, m.InputFields)
+		addCounts(c.EnumValues, m.EnumValues)
+		addCounts(c.ReturnedEnumValues, m.ReturnedEnumValues)
+	}
+	return usage
+}
+
+func addCounts(counts map[string]int64, metrics map[string]int32) {
+	for k, v := range metrics {
+		counts[k] += int64(v)
+	}
+}
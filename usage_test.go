@@ -0,0 +1,37 @@
+package graphmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/graphmetrics/graphmetrics-go/client"
+	"github.com/graphmetrics/graphmetrics-go/signature"
+)
+
+func TestUsage_SchemaUsage(t *testing.T) {
+	aggregator := NewAggregator(&Configuration{
+		Advanced: &AdvancedConfiguration{MaxFields: 1},
+	})
+
+	for _, field := range []string{"field", "field", "other"} {
+		aggregator.processField(&FieldMessage{
+			TypeName:  "Query",
+			FieldName: field,
+			Duration:  time.Millisecond,
+			Client:    client.Details{Name: "ios", Version: "5.2"},
+		})
+	}
+	aggregator.processOperation(&OperationMessage{
+		Hash:     "a",
+		Duration: time.Millisecond,
+		Client:   client.Details{Name: "ios", Version: "5.2"},
+		Usage:    signature.Usage{Arguments: []string{"Query.field(id)"}},
+	})
+
+	usage := schemaUsage(aggregator.metrics)
+	assert.Equal(t, map[string]int64{"Query.field": 2}, usage.Clients["ios 5.2"].Fields)
+	assert.Equal(t, int64(1), usage.Clients["ios 5.2"].OverflowedFields)
+	assert.Equal(t, map[string]int64{"Query.field(id)": 1}, usage.Clients["ios 5.2"].Arguments)
+}